@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 
 	kubernetes_client "github.com/TheisFerre/kubernetes-mcp-server/pkg/client"
@@ -10,12 +11,27 @@ import (
 
 func main() {
 
-	kubernetesClient, err := kubernetes_client.NewKubernetesClient()
+	disableSecretReveal := flag.Bool("disable-secret-reveal", false, "Never return secret values in clear text, even when requested with reveal, or issue service account tokens")
+	flag.Parse()
+
+	kubernetesConfig, err := kubernetes_client.NewKubernetesConfig()
+	if err != nil {
+		fmt.Printf("Error creating Kubernetes config: %v\n", err)
+		return
+	}
+
+	kubernetesClient, err := kubernetes_client.NewKubernetesClient(kubernetesConfig)
 	if err != nil {
 		fmt.Printf("Error creating Kubernetes client: %v\n", err)
 		return
 	}
 
+	dynamicClient, err := kubernetes_client.NewDynamicClient(kubernetesConfig)
+	if err != nil {
+		fmt.Printf("Error creating Kubernetes dynamic client: %v\n", err)
+		return
+	}
+
 	//Create a new MCP server
 	s := server.NewMCPServer(
 		"Kubernetes MCP Server",
@@ -24,7 +40,7 @@ func main() {
 		server.WithRecovery(),
 	)
 
-	tools.InitializeTools(s, kubernetesClient)
+	tools.InitializeTools(s, kubernetesConfig, kubernetesClient, dynamicClient, !*disableSecretReveal)
 
 	// Start the server
 	if err := server.ServeStdio(s); err != nil {
@@ -4,14 +4,16 @@ package client
 
 import (
 	"fmt"
+	"os"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
 
-func NewKubernetesClient() (*kubernetes.Clientset, error) {
+func NewKubernetesConfig() (*rest.Config, error) {
 	// Create a Kubernetes client configuration
 	var config *rest.Config
 	var err error
@@ -32,6 +34,10 @@ func NewKubernetesClient() (*kubernetes.Clientset, error) {
 		return nil, fmt.Errorf("failed to create Kubernetes config")
 	}
 
+	return config, nil
+}
+
+func NewKubernetesClient(config *rest.Config) (*kubernetes.Clientset, error) {
 	// Create a new Kubernetes clientset
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -40,3 +46,29 @@ func NewKubernetesClient() (*kubernetes.Clientset, error) {
 
 	return clientset, nil
 }
+
+func NewDynamicClient(config *rest.Config) (*dynamic.DynamicClient, error) {
+	// Create a new dynamic client for resources without a typed client
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes dynamic client: %w", err)
+	}
+
+	return dynamicClient, nil
+}
+
+func CertificateAuthorityData(config *rest.Config) ([]byte, error) {
+	// Return the CA bundle the client trusts, read from the CA file when it is not inlined
+	if len(config.CAData) > 0 {
+		return config.CAData, nil
+	}
+	if config.CAFile == "" {
+		return nil, nil
+	}
+	caData, err := os.ReadFile(config.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate authority file: %w", err)
+	}
+
+	return caData, nil
+}
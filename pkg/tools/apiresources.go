@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// apiResourceFilter selects the API resources to return. Empty fields match everything.
+type apiResourceFilter struct {
+	// Group is the API group, or core for the core API.
+	Group string
+	Verb  string
+	// Name matches a substring of the resource name, singular name, kind or a short name.
+	Name string
+}
+
+func (f apiResourceFilter) matchesGroup(group string) bool {
+	return f.Group == "" || f.Group == group || (f.Group == "core" && group == "")
+}
+
+// apiResources is the result of the list action, like kubectl api-resources.
+type apiResources struct {
+	Resources []apiResourceInfo `json:"resources"`
+	// Unavailable lists the group versions that could not be discovered, e.g. because an aggregated API is down.
+	Unavailable []string `json:"unavailable,omitempty"`
+}
+
+type apiResourceInfo struct {
+	Name       string   `json:"name"`
+	ShortNames []string `json:"shortNames,omitempty"`
+	APIVersion string   `json:"apiVersion"`
+	Namespaced bool     `json:"namespaced"`
+	Kind       string   `json:"kind"`
+	Verbs      []string `json:"verbs"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+func apiResourcesMCPResponse(action string, filter apiResourceFilter, refresh bool, discoveryClient discovery.CachedDiscoveryInterface) (*mcp.CallToolResult, error) {
+
+	if refresh {
+		discoveryClient.Invalidate()
+	}
+
+	switch action {
+	case "list":
+		resources, err := listAPIResources(discoveryClient, filter)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(resources), nil
+	case "versions":
+		groups, err := discoveryClient.ServerGroups()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		versions := []string{}
+		for _, group := range groups.Groups {
+			if !filter.matchesGroup(group.Name) {
+				continue
+			}
+			for _, version := range group.Versions {
+				versions = append(versions, version.GroupVersion)
+			}
+		}
+		sort.Strings(versions)
+		return mcp.NewToolResultStructuredOnly(versions), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// listAPIResources returns every resource in every discovered group version that matches filter, without subresources.
+func listAPIResources(discoveryClient discovery.DiscoveryInterface, filter apiResourceFilter) (*apiResources, error) {
+	result := &apiResources{Resources: []apiResourceInfo{}}
+	_, resourceLists, err := discoveryClient.ServerGroupsAndResources()
+	// Partial discovery failures are common with broken aggregated APIs; use whatever was discovered.
+	var groupDiscoveryFailed *discovery.ErrGroupDiscoveryFailed
+	if errors.As(err, &groupDiscoveryFailed) {
+		for groupVersion := range groupDiscoveryFailed.Groups {
+			result.Unavailable = append(result.Unavailable, groupVersion.String())
+		}
+		sort.Strings(result.Unavailable)
+	} else if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(filter.Name)
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+		if !filter.matchesGroup(groupVersion.Group) {
+			continue
+		}
+		for _, resource := range resourceList.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			if filter.Verb != "" && !slices.Contains(resource.Verbs, filter.Verb) {
+				continue
+			}
+			if name != "" && !apiResourceNameMatches(resource.Name, resource.SingularName, resource.Kind, resource.ShortNames, name) {
+				continue
+			}
+			result.Resources = append(result.Resources, apiResourceInfo{
+				Name:       resource.Name,
+				ShortNames: resource.ShortNames,
+				APIVersion: resourceList.GroupVersion,
+				Namespaced: resource.Namespaced,
+				Kind:       resource.Kind,
+				Verbs:      resource.Verbs,
+				Categories: resource.Categories,
+			})
+		}
+	}
+	sort.SliceStable(result.Resources, func(i, j int) bool {
+		if result.Resources[i].Name != result.Resources[j].Name {
+			return result.Resources[i].Name < result.Resources[j].Name
+		}
+		return result.Resources[i].APIVersion < result.Resources[j].APIVersion
+	})
+	return result, nil
+}
+
+func apiResourceNameMatches(name string, singularName string, kind string, shortNames []string, search string) bool {
+	for _, candidate := range append([]string{name, singularName, kind}, shortNames...) {
+		if strings.Contains(strings.ToLower(candidate), search) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// fieldManager identifies this server as the owner of fields set through server-side apply.
+const fieldManager = "kubernetes-mcp-server"
+
+// applyPatch turns the object in resourceSpec into a server-side apply patch for the named object,
+// filling in apiVersion, kind, name and namespace.
+func applyPatch(resourceSpec string, apiVersion string, kind string, name string, namespace string) ([]byte, error) {
+	if resourceSpec == "" {
+		return nil, fmt.Errorf("resourceSpec is required for apply action")
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal([]byte(resourceSpec), &object); err != nil {
+		return nil, fmt.Errorf("invalid resourceSpec JSON: %w", err)
+	}
+	metadata, _ := object["metadata"].(map[string]any)
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	metadata["name"] = name
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+	object["metadata"] = metadata
+	object["apiVersion"] = apiVersion
+	object["kind"] = kind
+
+	patch, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	return patch, nil
+}
@@ -0,0 +1,245 @@
+package tools
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	networkingv1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+)
+
+// caCertKey is the conventional key for the CA bundle of a TLS secret.
+const caCertKey = "ca.crt"
+
+// tlsSecretReport describes the certificates stored in a single TLS secret.
+type tlsSecretReport struct {
+	Namespace      string            `json:"namespace"`
+	Name           string            `json:"name"`
+	Certificates   []certificateInfo `json:"certificates"`
+	CACertificates []certificateInfo `json:"caCertificates,omitempty"`
+	ChainValid     bool              `json:"chainValid"`
+	ChainError     string            `json:"chainError,omitempty"`
+	Ingresses      []string          `json:"ingresses,omitempty"`
+	Error          string            `json:"error,omitempty"`
+}
+
+type certificateInfo struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	DNSNames      []string  `json:"dnsNames,omitempty"`
+	IPAddresses   []string  `json:"ipAddresses,omitempty"`
+	NotBefore     time.Time `json:"notBefore"`
+	NotAfter      time.Time `json:"notAfter"`
+	DaysRemaining int       `json:"daysRemaining"`
+	IsCA          bool      `json:"isCA"`
+}
+
+// expiringCertificate is a certificate that expires within the requested number of days.
+type expiringCertificate struct {
+	Namespace     string    `json:"namespace"`
+	Secret        string    `json:"secret"`
+	Key           string    `json:"key"`
+	Subject       string    `json:"subject"`
+	NotAfter      time.Time `json:"notAfter"`
+	DaysRemaining int       `json:"daysRemaining"`
+	Ingresses     []string  `json:"ingresses,omitempty"`
+}
+
+func certificateMCPResponse(ctx context.Context, name string, namespace string, action string, days int, secretInterface v1.SecretInterface, ingressInterface networkingv1.IngressInterface) (*mcp.CallToolResult, error) {
+
+	var secrets []corev1.Secret
+	if name != "" {
+		if namespace == "" {
+			return mcp.NewToolResultError("namespace is required when name is set"), nil
+		}
+		secret, err := secretInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		secrets = append(secrets, *secret)
+	} else {
+		secretList, err := secretInterface.List(
+			ctx,
+			metav1.ListOptions{FieldSelector: "type=" + string(corev1.SecretTypeTLS)},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		secrets = secretList.Items
+	}
+
+	ingressesBySecret, err := ingressesByTLSSecret(ctx, ingressInterface)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	now := time.Now()
+	var reports []tlsSecretReport
+	for _, secret := range secrets {
+		report := inspectTLSSecret(&secret, now)
+		report.Ingresses = ingressesBySecret[secret.Namespace+"/"+secret.Name]
+		reports = append(reports, *report)
+	}
+
+	switch action {
+	case "inspect":
+		return mcp.NewToolResultStructuredOnly(reports), nil
+	case "expiring":
+		if days <= 0 {
+			days = 30
+		}
+		deadline := now.AddDate(0, 0, days)
+		expiring := []expiringCertificate{}
+		for _, report := range reports {
+			for key, certificates := range map[string][]certificateInfo{
+				corev1.TLSCertKey: report.Certificates,
+				caCertKey:         report.CACertificates,
+			} {
+				for _, certificate := range certificates {
+					if certificate.NotAfter.After(deadline) {
+						continue
+					}
+					expiring = append(expiring, expiringCertificate{
+						Namespace:     report.Namespace,
+						Secret:        report.Name,
+						Key:           key,
+						Subject:       certificate.Subject,
+						NotAfter:      certificate.NotAfter,
+						DaysRemaining: certificate.DaysRemaining,
+						Ingresses:     report.Ingresses,
+					})
+				}
+			}
+		}
+		sort.Slice(expiring, func(i, j int) bool {
+			return expiring[i].NotAfter.Before(expiring[j].NotAfter)
+		})
+		return mcp.NewToolResultStructuredOnly(expiring), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// inspectTLSSecret parses tls.crt and ca.crt of secret and verifies the certificate chain at now.
+// Secrets that cannot be parsed are reported with an error rather than failing the whole scan.
+func inspectTLSSecret(secret *corev1.Secret, now time.Time) *tlsSecretReport {
+	report := &tlsSecretReport{
+		Namespace: secret.Namespace,
+		Name:      secret.Name,
+	}
+
+	certificates, err := parseCertificates(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		report.Error = fmt.Sprintf("failed to parse %s: %v", corev1.TLSCertKey, err)
+		return report
+	}
+	if len(certificates) == 0 {
+		report.Error = "no certificate found in " + corev1.TLSCertKey
+		return report
+	}
+	caCertificates, err := parseCertificates(secret.Data[caCertKey])
+	if err != nil {
+		report.Error = fmt.Sprintf("failed to parse %s: %v", caCertKey, err)
+		return report
+	}
+
+	for _, certificate := range certificates {
+		report.Certificates = append(report.Certificates, describeCertificate(certificate, now))
+	}
+	for _, certificate := range caCertificates {
+		report.CACertificates = append(report.CACertificates, describeCertificate(certificate, now))
+	}
+
+	// Verify the leaf against the intermediates in tls.crt and the roots in ca.crt, falling back to the system roots.
+	options := x509.VerifyOptions{
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, certificate := range certificates[1:] {
+		options.Intermediates.AddCert(certificate)
+	}
+	if len(caCertificates) > 0 {
+		options.Roots = x509.NewCertPool()
+		for _, certificate := range caCertificates {
+			options.Roots.AddCert(certificate)
+		}
+	}
+	if _, err := certificates[0].Verify(options); err != nil {
+		report.ChainError = err.Error()
+	} else {
+		report.ChainValid = true
+	}
+	return report
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certificates, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+}
+
+func describeCertificate(certificate *x509.Certificate, now time.Time) certificateInfo {
+	info := certificateInfo{
+		Subject:       certificate.Subject.String(),
+		Issuer:        certificate.Issuer.String(),
+		DNSNames:      certificate.DNSNames,
+		NotBefore:     certificate.NotBefore,
+		NotAfter:      certificate.NotAfter,
+		DaysRemaining: int(certificate.NotAfter.Sub(now).Hours() / 24),
+		IsCA:          certificate.IsCA,
+	}
+	for _, ip := range certificate.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	return info
+}
+
+// ingressesByTLSSecret maps namespace/secret to the ingresses that reference the secret in their TLS section.
+func ingressesByTLSSecret(ctx context.Context, ingressInterface networkingv1.IngressInterface) (map[string][]string, error) {
+	ingresses, err := ingressInterface.List(
+		ctx,
+		metav1.ListOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]string)
+	for _, ingress := range ingresses.Items {
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+			key := ingress.Namespace + "/" + tls.SecretName
+			// Several TLS entries of one ingress may share a secret; ingresses are listed one at a time.
+			if names := result[key]; len(names) > 0 && names[len(names)-1] == ingress.Name {
+				continue
+			}
+			result[key] = append(result[key], ingress.Name)
+		}
+	}
+	return result, nil
+}
@@ -3,14 +3,25 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/ptr"
 )
 
-func configmapMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, configmapInterface v1.ConfigMapInterface) (*mcp.CallToolResult, error) {
+// configUpdateResult is returned by update, patch and apply when consumers are restarted.
+type configUpdateResult struct {
+	Object   json.RawMessage `json:"object"`
+	Restarts []restartResult `json:"restarts"`
+}
+
+func configmapMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, restart bool, timeout int, configmapInterface v1.ConfigMapInterface, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
 
 	switch action {
 	case "create":
@@ -21,9 +32,18 @@ func configmapMCPResponse(ctx context.Context, name string, namespace string, ac
 
 		configmapJson := []byte(resourceSpec)
 		var configmapSpec corev1.ConfigMap
-		if err := json.Unmarshal(configmapJson, &configmapSpec); err != nil {
+		sourcedConfigMap, err := configMapFromSources(resourceSpec)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if sourcedConfigMap != nil {
+			configmapSpec = *sourcedConfigMap
+		} else if err := json.Unmarshal(configmapJson, &configmapSpec); err != nil {
 			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
 		}
+		if size := configMapSize(&configmapSpec); size > maxConfigMapSize {
+			return mcp.NewToolResultError(fmt.Sprintf("ConfigMap data is %d bytes, which exceeds the %d byte limit", size, maxConfigMapSize)), nil
+		}
 
 		configmap, err := configmapInterface.Create(
 			ctx,
@@ -57,17 +77,53 @@ func configmapMCPResponse(ctx context.Context, name string, namespace string, ac
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 		return mcp.NewToolResultText("Deleted configmap " + name + " in namespace " + namespace), nil
-	case "update":
-		configmap, err := configmapInterface.Update(
-			ctx,
-			&corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      name,
-					Namespace: namespace,
+	case "update", "patch", "apply":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for " + action + " action"), nil
+		}
+
+		var configmap *corev1.ConfigMap
+		var err error
+		switch action {
+		case "update":
+			var configmapSpec corev1.ConfigMap
+			if err := json.Unmarshal([]byte(resourceSpec), &configmapSpec); err != nil {
+				return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+			}
+			configmap, err = configmapInterface.Update(
+				ctx,
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        name,
+						Namespace:   namespace,
+						Labels:      configmapSpec.Labels,
+						Annotations: configmapSpec.Annotations,
+					},
+					Data:       configmapSpec.Data,
+					BinaryData: configmapSpec.BinaryData,
 				},
-			},
-			metav1.UpdateOptions{},
-		)
+				metav1.UpdateOptions{},
+			)
+		case "patch":
+			configmap, err = configmapInterface.Patch(
+				ctx,
+				name,
+				types.MergePatchType,
+				[]byte(resourceSpec),
+				metav1.PatchOptions{},
+			)
+		case "apply":
+			var patch []byte
+			if patch, err = applyPatch(resourceSpec, "v1", configMapKind, name, namespace); err == nil {
+				configmap, err = configmapInterface.Patch(
+					ctx,
+					name,
+					types.ApplyPatchType,
+					patch,
+					metav1.PatchOptions{FieldManager: fieldManager, Force: ptr.To(true)},
+				)
+			}
+		}
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
@@ -75,7 +131,18 @@ func configmapMCPResponse(ctx context.Context, name string, namespace string, ac
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal updated configmap: " + err.Error()), nil
 		}
-		return mcp.NewToolResultStructuredOnly(updatedConfigMapSpec), nil
+		if !restart {
+			return mcp.NewToolResultStructuredOnly(updatedConfigMapSpec), nil
+		}
+
+		restarts, err := restartConsumers(ctx, kubernetesClient, namespace, configMapKind, name, time.Duration(timeout)*time.Second)
+		if err != nil {
+			return mcp.NewToolResultError("Updated configmap but failed to restart consumers: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(configUpdateResult{
+			Object:   updatedConfigMapSpec,
+			Restarts: restarts,
+		}), nil
 	case "get":
 		configmap, err := configmapInterface.Get(
 			ctx,
@@ -103,6 +170,12 @@ func configmapMCPResponse(ctx context.Context, name string, namespace string, ac
 			configmapNames = append(configmapNames, configmap.Name)
 		}
 		return mcp.NewToolResultStructuredOnly(configmapNames), nil
+	case "consumers":
+		consumers, err := findConsumers(ctx, kubernetesClient, namespace, configMapKind, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(consumers), nil
 	}
 	return mcp.NewToolResultError("Unknown action: " + action), nil
 }
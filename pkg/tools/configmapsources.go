@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// maxConfigMapSize is the limit the API server enforces on the total size of a configmap's data.
+const maxConfigMapSize = 1024 * 1024
+
+// configMapSources mirrors the --from-literal, --from-file and --from-env-file flags of
+// kubectl create configmap, with file contents passed inline.
+type configMapSources struct {
+	FromLiteral   []string             `json:"fromLiteral"`
+	FromFile      []configMapFile      `json:"fromFile"`
+	FromDirectory []configMapDirectory `json:"fromDirectory"`
+	FromEnvFile   []string             `json:"fromEnvFile"`
+}
+
+type configMapFile struct {
+	Path string `json:"path"`
+	// Key defaults to the base name of Path.
+	Key     string `json:"key"`
+	Content string `json:"content"`
+	// Encoding is either empty for plain text or "base64" for binary content.
+	Encoding string `json:"encoding"`
+}
+
+// configMapDirectory adds every file directly inside Path, like --from-file=<directory>.
+type configMapDirectory struct {
+	Path  string          `json:"path"`
+	Files []configMapFile `json:"files"`
+}
+
+func (s configMapSources) empty() bool {
+	return len(s.FromLiteral) == 0 && len(s.FromFile) == 0 && len(s.FromDirectory) == 0 && len(s.FromEnvFile) == 0
+}
+
+// configMapFromSources builds configmap data from the sources in resourceSpec. It returns nil when
+// resourceSpec holds no sources, so the caller can treat it as a plain configmap instead.
+func configMapFromSources(resourceSpec string) (*corev1.ConfigMap, error) {
+	var sources configMapSources
+	if err := json.Unmarshal([]byte(resourceSpec), &sources); err != nil {
+		return nil, fmt.Errorf("invalid resourceSpec JSON: %w", err)
+	}
+	if sources.empty() {
+		return nil, nil
+	}
+
+	configmap := &corev1.ConfigMap{
+		Data:       map[string]string{},
+		BinaryData: map[string][]byte{},
+	}
+	add := func(key string, value []byte) error {
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+		_, inData := configmap.Data[key]
+		_, inBinaryData := configmap.BinaryData[key]
+		if inData || inBinaryData {
+			return fmt.Errorf("duplicate key %q", key)
+		}
+		if utf8.Valid(value) {
+			configmap.Data[key] = string(value)
+		} else {
+			configmap.BinaryData[key] = value
+		}
+		return nil
+	}
+
+	if len(sources.FromLiteral) > 0 {
+		literals, err := parseLiterals(sources.FromLiteral)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range literals {
+			if err := add(key, []byte(value)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	files := sources.FromFile
+	for _, directory := range sources.FromDirectory {
+		for _, file := range directory.Files {
+			// Like kubectl, only files directly inside the directory are added.
+			if strings.Contains(strings.Trim(file.Path, "/"), "/") {
+				continue
+			}
+			files = append(files, file)
+		}
+	}
+	for _, file := range files {
+		key := file.Key
+		if key == "" {
+			key = path.Base(file.Path)
+		}
+		content, err := fileContent(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(key, content); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, envFile := range sources.FromEnvFile {
+		variables, err := parseEnvFile(envFile)
+		if err != nil {
+			return nil, fmt.Errorf("fromEnvFile[%d]: %w", i, err)
+		}
+		for _, variable := range variables {
+			if err := add(variable[0], []byte(variable[1])); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return configmap, nil
+}
+
+func fileContent(file configMapFile) ([]byte, error) {
+	switch file.Encoding {
+	case "":
+		return []byte(file.Content), nil
+	case "base64":
+		content, err := base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 content for %s: %w", file.Path, err)
+		}
+		return content, nil
+	}
+	return nil, fmt.Errorf("unknown encoding %q for %s", file.Encoding, file.Path)
+}
+
+// parseEnvFile parses KEY=VALUE lines the way kubectl --from-env-file does, skipping blank lines and comments.
+func parseEnvFile(content string) ([][2]string, error) {
+	var variables [][2]string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Bytes()
+		if lineNumber == 1 {
+			line = bytes.TrimPrefix(line, []byte("\xef\xbb\xbf"))
+		}
+		trimmed := strings.TrimLeft(string(line), " \t")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(trimmed, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+		if errs := validation.IsEnvVarName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("line %d: invalid key %q: %s", lineNumber, key, strings.Join(errs, "; "))
+		}
+		variables = append(variables, [2]string{key, value})
+	}
+	return variables, scanner.Err()
+}
+
+// configMapSize returns the total size of the keys and values of configmap.
+func configMapSize(configmap *corev1.ConfigMap) int {
+	size := 0
+	for key, value := range configmap.Data {
+		size += len(key) + len(value)
+	}
+	for key, value := range configmap.BinaryData {
+		size += len(key) + len(value)
+	}
+	return size
+}
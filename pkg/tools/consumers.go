@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	configMapKind = "ConfigMap"
+	secretKind    = "Secret"
+)
+
+// consumerKinds are the workload kinds findConsumers scans. Objects controlled by one of them are reported
+// through their controller.
+var consumerKinds = map[schema.GroupKind]bool{
+	{Group: "apps", Kind: "Deployment"}:  true,
+	{Group: "apps", Kind: "ReplicaSet"}:  true,
+	{Group: "apps", Kind: "StatefulSet"}: true,
+	{Group: "apps", Kind: "DaemonSet"}:   true,
+	{Group: "batch", Kind: "Job"}:        true,
+	{Group: "batch", Kind: "CronJob"}:    true,
+}
+
+// consumer is a pod or workload that references a configmap or secret.
+type consumer struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	Usages []string `json:"usages"`
+	// ControlledBy is the controller of the workload when it is managed by something else, e.g. an operator.
+	ControlledBy string `json:"controlledBy,omitempty"`
+}
+
+// findConsumers scans pods and the pod templates of deployments, replicasets, statefulsets, daemonsets,
+// jobs and cronjobs in namespace for references to the configmap or secret called name.
+// Objects created by one of these workloads are reported through their controller instead.
+func findConsumers(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, kind string, name string) ([]consumer, error) {
+	consumers := []consumer{}
+	add := func(workloadKind string, meta metav1.ObjectMeta, podSpec *corev1.PodSpec) {
+		controller := metav1.GetControllerOf(&meta)
+		if controller != nil {
+			groupVersion, err := schema.ParseGroupVersion(controller.APIVersion)
+			if err == nil && consumerKinds[groupVersion.WithKind(controller.Kind).GroupKind()] {
+				return
+			}
+		}
+		if usages := podSpecReferences(podSpec, kind, name); len(usages) > 0 {
+			found := consumer{Kind: workloadKind, Name: meta.Name, Usages: usages}
+			if controller != nil {
+				found.ControlledBy = controller.Kind + "/" + controller.Name
+			}
+			consumers = append(consumers, found)
+		}
+	}
+
+	deployments, err := kubernetesClient.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		add("Deployment", deployment.ObjectMeta, &deployment.Spec.Template.Spec)
+	}
+
+	replicasets, err := kubernetesClient.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, replicaset := range replicasets.Items {
+		add("ReplicaSet", replicaset.ObjectMeta, &replicaset.Spec.Template.Spec)
+	}
+
+	statefulsets, err := kubernetesClient.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, statefulset := range statefulsets.Items {
+		add("StatefulSet", statefulset.ObjectMeta, &statefulset.Spec.Template.Spec)
+	}
+
+	daemonsets, err := kubernetesClient.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, daemonset := range daemonsets.Items {
+		add("DaemonSet", daemonset.ObjectMeta, &daemonset.Spec.Template.Spec)
+	}
+
+	cronjobs, err := kubernetesClient.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cronjob := range cronjobs.Items {
+		add("CronJob", cronjob.ObjectMeta, &cronjob.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	jobs, err := kubernetesClient.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs.Items {
+		add("Job", job.ObjectMeta, &job.Spec.Template.Spec)
+	}
+
+	pods, err := kubernetesClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		add("Pod", pod.ObjectMeta, &pod.Spec)
+	}
+
+	return consumers, nil
+}
+
+// podSpecReferences describes every way podSpec references the configmap or secret called name.
+func podSpecReferences(podSpec *corev1.PodSpec, kind string, name string) []string {
+	var usages []string
+
+	for _, volume := range podSpec.Volumes {
+		switch {
+		case kind == configMapKind && volume.ConfigMap != nil && volume.ConfigMap.Name == name,
+			kind == secretKind && volume.Secret != nil && volume.Secret.SecretName == name:
+			usages = append(usages, fmt.Sprintf("volume %s", volume.Name))
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if kind == configMapKind && source.ConfigMap != nil && source.ConfigMap.Name == name ||
+					kind == secretKind && source.Secret != nil && source.Secret.Name == name {
+					usages = append(usages, fmt.Sprintf("projected volume %s", volume.Name))
+				}
+			}
+		}
+	}
+
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for _, container := range containers {
+			for _, envFrom := range container.EnvFrom {
+				if kind == configMapKind && envFrom.ConfigMapRef != nil && envFrom.ConfigMapRef.Name == name ||
+					kind == secretKind && envFrom.SecretRef != nil && envFrom.SecretRef.Name == name {
+					usages = append(usages, fmt.Sprintf("envFrom in container %s", container.Name))
+				}
+			}
+			for _, env := range container.Env {
+				if env.ValueFrom == nil {
+					continue
+				}
+				if ref := env.ValueFrom.ConfigMapKeyRef; kind == configMapKind && ref != nil && ref.Name == name {
+					usages = append(usages, fmt.Sprintf("env %s from key %s in container %s", env.Name, ref.Key, container.Name))
+				}
+				if ref := env.ValueFrom.SecretKeyRef; kind == secretKind && ref != nil && ref.Name == name {
+					usages = append(usages, fmt.Sprintf("env %s from key %s in container %s", env.Name, ref.Key, container.Name))
+				}
+			}
+		}
+	}
+
+	if kind == secretKind {
+		for _, pullSecret := range podSpec.ImagePullSecrets {
+			if pullSecret.Name == name {
+				usages = append(usages, "imagePullSecrets")
+			}
+		}
+	}
+	return usages
+}
@@ -3,14 +3,29 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	v1 "k8s.io/client-go/kubernetes/typed/batch/v1"
 )
 
-func cronjobMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, cronjobInterface v1.CronJobInterface) (*mcp.CallToolResult, error) {
+// cronjobSchedule summarizes when a cronjob last ran and when it will run next.
+type cronjobSchedule struct {
+	Schedule           string       `json:"schedule"`
+	TimeZone           string       `json:"timeZone"`
+	Suspended          bool         `json:"suspended"`
+	ActiveJobs         int          `json:"activeJobs"`
+	NextScheduleTimes  []time.Time  `json:"nextScheduleTimes"`
+	LastScheduleTime   *metav1.Time `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+}
+
+func cronjobMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, images []string, count int, cronjobInterface v1.CronJobInterface, jobInterface v1.JobInterface) (*mcp.CallToolResult, error) {
 
 	switch action {
 	case "create":
@@ -40,6 +55,7 @@ func cronjobMCPResponse(ctx context.Context, name string, namespace string, acti
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		redactPodSpec(&cronjob.ObjectMeta, &cronjob.Spec.JobTemplate.Spec.Template.Spec)
 		createdCronJobSpec, err := json.Marshal(cronjob)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal created cronjob: " + err.Error()), nil
@@ -70,6 +86,7 @@ func cronjobMCPResponse(ctx context.Context, name string, namespace string, acti
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&cronjob.ObjectMeta, &cronjob.Spec.JobTemplate.Spec.Template.Spec)
 		updatedCronJobSpec, err := json.Marshal(cronjob)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal updated cronjob: " + err.Error()), nil
@@ -84,6 +101,7 @@ func cronjobMCPResponse(ctx context.Context, name string, namespace string, acti
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&cronjob.ObjectMeta, &cronjob.Spec.JobTemplate.Spec.Template.Spec)
 		cronjobSpec, err := json.Marshal(cronjob)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal cronjob: " + err.Error()), nil
@@ -102,6 +120,163 @@ func cronjobMCPResponse(ctx context.Context, name string, namespace string, acti
 			cronjobNames = append(cronjobNames, cronjob.Name)
 		}
 		return mcp.NewToolResultStructuredOnly(cronjobNames), nil
+	case "set_image":
+		cronjob, err := cronjobInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, changes, err := setImagePatch(&cronjob.Spec.JobTemplate.Spec.Template.Spec, images, "spec", "jobTemplate", "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		_, err = cronjobInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(changes), nil
+	case "edit_template":
+		cronjob, err := cronjobInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, container, err := editTemplatePatch(&cronjob.Spec.JobTemplate.Spec.Template.Spec, resourceSpec, "spec", "jobTemplate", "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		cronjob, err = cronjobInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactPodSpec(&cronjob.ObjectMeta, &cronjob.Spec.JobTemplate.Spec.Template.Spec)
+		return mcp.NewToolResultStructuredOnly(findContainer(&cronjob.Spec.JobTemplate.Spec.Template.Spec, container)), nil
+	case "trigger":
+		cronjob, err := cronjobInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Mirror kubectl create job --from=cronjob/<name>.
+		annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+		for key, value := range cronjob.Spec.JobTemplate.Annotations {
+			annotations[key] = value
+		}
+		jobName := name
+		if len(jobName) > 50 {
+			jobName = jobName[:50]
+		}
+		job, err := jobInterface.Create(
+			ctx,
+			&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        jobName + "-manual-" + utilrand.String(5),
+					Namespace:   namespace,
+					Labels:      cronjob.Spec.JobTemplate.Labels,
+					Annotations: annotations,
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(cronjob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+					},
+				},
+				Spec: cronjob.Spec.JobTemplate.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactPodSpec(&job.ObjectMeta, &job.Spec.Template.Spec)
+		createdJobSpec, err := json.Marshal(job)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created job: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(createdJobSpec), nil
+	case "suspend", "resume":
+		suspend := action == "suspend"
+		patch, err := json.Marshal(map[string]any{"spec": map[string]any{"suspend": suspend}})
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal patch: " + err.Error()), nil
+		}
+		_, err = cronjobInterface.Patch(
+			ctx,
+			name,
+			types.MergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if suspend {
+			return mcp.NewToolResultText("Suspended cronjob " + name + " in namespace " + namespace), nil
+		}
+		return mcp.NewToolResultText("Resumed cronjob " + name + " in namespace " + namespace), nil
+	case "schedule":
+		cronjob, err := cronjobInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		schedule, err := parseCronSchedule(cronjob.Spec.Schedule)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		// Without spec.timeZone the controller uses the kube-controller-manager time zone, which is UTC in most clusters.
+		timeZone := "UTC"
+		if cronjob.Spec.TimeZone != nil {
+			timeZone = *cronjob.Spec.TimeZone
+		}
+		location, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown time zone %q: %v", timeZone, err)), nil
+		}
+		if count <= 0 {
+			count = 5
+		}
+
+		result := cronjobSchedule{
+			Schedule:           cronjob.Spec.Schedule,
+			TimeZone:           timeZone,
+			Suspended:          cronjob.Spec.Suspend != nil && *cronjob.Spec.Suspend,
+			ActiveJobs:         len(cronjob.Status.Active),
+			LastScheduleTime:   cronjob.Status.LastScheduleTime,
+			LastSuccessfulTime: cronjob.Status.LastSuccessfulTime,
+		}
+		next := time.Now().In(location)
+		for len(result.NextScheduleTimes) < count {
+			var ok bool
+			if next, ok = schedule.next(next); !ok {
+				break
+			}
+			result.NextScheduleTimes = append(result.NextScheduleTimes, next)
+		}
+		return mcp.NewToolResultStructuredOnly(result), nil
 	}
 	return mcp.NewToolResultError("Unknown action: " + action), nil
 }
@@ -7,10 +7,11 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 )
 
-func daemonsetMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, daemonsetInterface v1.DaemonSetInterface) (*mcp.CallToolResult, error) {
+func daemonsetMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, images []string, daemonsetInterface v1.DaemonSetInterface) (*mcp.CallToolResult, error) {
 
 	switch action {
 	case "create":
@@ -40,6 +41,7 @@ func daemonsetMCPResponse(ctx context.Context, name string, namespace string, ac
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		redactPodSpec(&daemonset.ObjectMeta, &daemonset.Spec.Template.Spec)
 		createdDaemonSetSpec, err := json.Marshal(daemonset)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal created daemonset: " + err.Error()), nil
@@ -70,6 +72,7 @@ func daemonsetMCPResponse(ctx context.Context, name string, namespace string, ac
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&daemonset.ObjectMeta, &daemonset.Spec.Template.Spec)
 		updatedDaemonSetSpec, err := json.Marshal(daemonset)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal updated daemonset: " + err.Error()), nil
@@ -84,6 +87,7 @@ func daemonsetMCPResponse(ctx context.Context, name string, namespace string, ac
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&daemonset.ObjectMeta, &daemonset.Spec.Template.Spec)
 		daemonsetSpec, err := json.Marshal(daemonset)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal daemonset: " + err.Error()), nil
@@ -102,6 +106,55 @@ func daemonsetMCPResponse(ctx context.Context, name string, namespace string, ac
 			daemonsetNames = append(daemonsetNames, daemonset.Name)
 		}
 		return mcp.NewToolResultStructuredOnly(daemonsetNames), nil
+	case "set_image":
+		daemonset, err := daemonsetInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, changes, err := setImagePatch(&daemonset.Spec.Template.Spec, images, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		_, err = daemonsetInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(changes), nil
+	case "edit_template":
+		daemonset, err := daemonsetInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, container, err := editTemplatePatch(&daemonset.Spec.Template.Spec, resourceSpec, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		daemonset, err = daemonsetInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactPodSpec(&daemonset.ObjectMeta, &daemonset.Spec.Template.Spec)
+		return mcp.NewToolResultStructuredOnly(findContainer(&daemonset.Spec.Template.Spec, container)), nil
 	}
 	return mcp.NewToolResultError("Unknown action: " + action), nil
 }
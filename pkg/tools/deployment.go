@@ -7,10 +7,11 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 )
 
-func deploymentMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, deploymentInterface v1.DeploymentInterface) (*mcp.CallToolResult, error) {
+func deploymentMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, images []string, deploymentInterface v1.DeploymentInterface) (*mcp.CallToolResult, error) {
 
 	switch action {
 	case "create":
@@ -40,6 +41,7 @@ func deploymentMCPResponse(ctx context.Context, name string, namespace string, a
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		redactPodSpec(&deployment.ObjectMeta, &deployment.Spec.Template.Spec)
 		createdDeploymentSpec, err := json.Marshal(deployment)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal created deployment: " + err.Error()), nil
@@ -70,6 +72,7 @@ func deploymentMCPResponse(ctx context.Context, name string, namespace string, a
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&deployment.ObjectMeta, &deployment.Spec.Template.Spec)
 		updatedDeploymentSpec, err := json.Marshal(deployment)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal updated deployment: " + err.Error()), nil
@@ -84,6 +87,7 @@ func deploymentMCPResponse(ctx context.Context, name string, namespace string, a
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&deployment.ObjectMeta, &deployment.Spec.Template.Spec)
 		deploymentSpec, err := json.Marshal(deployment)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal deployment: " + err.Error()), nil
@@ -102,6 +106,55 @@ func deploymentMCPResponse(ctx context.Context, name string, namespace string, a
 			deploymentNames = append(deploymentNames, deployment.Name)
 		}
 		return mcp.NewToolResultStructuredOnly(deploymentNames), nil
+	case "set_image":
+		deployment, err := deploymentInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, changes, err := setImagePatch(&deployment.Spec.Template.Spec, images, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		_, err = deploymentInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(changes), nil
+	case "edit_template":
+		deployment, err := deploymentInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, container, err := editTemplatePatch(&deployment.Spec.Template.Spec, resourceSpec, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		deployment, err = deploymentInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactPodSpec(&deployment.ObjectMeta, &deployment.Spec.Template.Spec)
+		return mcp.NewToolResultStructuredOnly(findContainer(&deployment.Spec.Template.Spec, container)), nil
 	}
 	return mcp.NewToolResultError("Unknown action: " + action), nil
 }
@@ -0,0 +1,1142 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+// maxOwnerDepth bounds the owner chain walk, which normally ends after Pod -> ReplicaSet -> Deployment.
+const maxOwnerDepth = 5
+
+// describeWriter builds kubectl describe style output of "Label: value" lines, indented by section.
+type describeWriter struct {
+	builder strings.Builder
+	indent  int
+}
+
+func (w *describeWriter) line(label string, value any) {
+	w.text("%s: %v", label, value)
+}
+
+func (w *describeWriter) text(format string, args ...any) {
+	w.builder.WriteString(strings.Repeat("  ", w.indent))
+	fmt.Fprintf(&w.builder, format, args...)
+	w.builder.WriteString("\n")
+}
+
+// section writes title and the lines written by body indented below it, or <none> when body writes nothing.
+func (w *describeWriter) section(title string, body func()) {
+	w.text("%s:", title)
+	length := w.builder.Len()
+	w.indent++
+	body()
+	if w.builder.Len() == length {
+		w.text("<none>")
+	}
+	w.indent--
+}
+
+func (w *describeWriter) String() string {
+	return w.builder.String()
+}
+
+func describeMCPResponse(ctx context.Context, tool string, name string, namespace string, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
+	w := &describeWriter{}
+	var err error
+	switch tool {
+	case pod:
+		err = describePod(ctx, w, kubernetesClient, namespace, name)
+	case deployment:
+		err = describeDeployment(ctx, w, kubernetesClient, namespace, name)
+	case statefulset:
+		err = describeStatefulSet(ctx, w, kubernetesClient, namespace, name)
+	case daemonset:
+		err = describeDaemonSet(ctx, w, kubernetesClient, namespace, name)
+	case replicaset:
+		err = describeReplicaSet(ctx, w, kubernetesClient, namespace, name)
+	case job:
+		err = describeJob(ctx, w, kubernetesClient, namespace, name)
+	case cronjob:
+		err = describeCronJob(ctx, w, kubernetesClient, namespace, name)
+	case service:
+		err = describeService(ctx, w, kubernetesClient, namespace, name)
+	case configmap:
+		err = describeConfigMap(ctx, w, kubernetesClient, namespace, name)
+	case secret:
+		err = describeSecret(ctx, w, kubernetesClient, namespace, name)
+	case namespaceResource:
+		err = describeNamespace(ctx, w, kubernetesClient, name)
+	case node:
+		err = describeNode(ctx, w, kubernetesClient, name)
+	case persistentvolumeclaim:
+		err = describePersistentVolumeClaim(ctx, w, kubernetesClient, namespace, name)
+	case persistentvolume:
+		err = describePersistentVolume(ctx, w, kubernetesClient, name)
+	case storageclass:
+		err = describeStorageClass(ctx, w, kubernetesClient, name)
+	case ingress:
+		err = describeIngress(ctx, w, kubernetesClient, namespace, name)
+	case ingressclass:
+		err = describeIngressClass(ctx, w, kubernetesClient, name)
+	case networkpolicy:
+		err = describeNetworkPolicy(ctx, w, kubernetesClient, namespace, name)
+	case hpa:
+		err = describeHPA(ctx, w, kubernetesClient, namespace, name)
+	case serviceaccount:
+		err = describeServiceAccount(ctx, w, kubernetesClient, namespace, name)
+	case resourcequota:
+		err = describeResourceQuota(ctx, w, kubernetesClient, namespace, name)
+	case limitrange:
+		err = describeLimitRange(ctx, w, kubernetesClient, namespace, name)
+	case pdb:
+		err = describePodDisruptionBudget(ctx, w, kubernetesClient, namespace, name)
+	default:
+		return mcp.NewToolResultError("Describe is not supported for " + tool), nil
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	timeline, err := eventTimeline(ctx, kubernetesClient, namespace, eventFilter{Kind: toolKinds[tool], Name: name})
+	if err != nil {
+		return mcp.NewToolResultError("Failed to list events: " + err.Error()), nil
+	}
+	if len(timeline) > recentEventLimit {
+		timeline = timeline[len(timeline)-recentEventLimit:]
+	}
+	w.section("Events", func() {
+		for _, event := range timeline {
+			w.text("%s %s %s (x%d): %s", formatTime(event.LastSeen), event.Type, event.Reason, event.Count, strings.TrimSpace(event.Message))
+		}
+	})
+	return mcp.NewToolResultText(w.String()), nil
+}
+
+// describeMeta writes the header shared by every kind, including the chain of controllers owning the object.
+func describeMeta(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, meta *metav1.ObjectMeta) {
+	w.line("Name", meta.Name)
+	if meta.Namespace != "" {
+		w.line("Namespace", meta.Namespace)
+	}
+	w.line("Created", formatTime(meta.CreationTimestamp.Time))
+	w.line("Labels", formatMap(meta.Labels))
+	annotations := map[string]string{}
+	for key, value := range meta.Annotations {
+		if key != lastAppliedAnnotation {
+			annotations[key] = value
+		}
+	}
+	w.line("Annotations", formatMap(annotations))
+	if meta.DeletionTimestamp != nil {
+		w.line("Deleting since", formatTime(meta.DeletionTimestamp.Time))
+	}
+	if chain := ownerChain(ctx, kubernetesClient, meta); len(chain) > 0 {
+		w.line("Controlled By", strings.Join(chain, " -> "))
+	}
+}
+
+// ownerChain follows the controller references of an object upwards, e.g. ReplicaSet/web-5d8f -> Deployment/web.
+func ownerChain(ctx context.Context, kubernetesClient kubernetes.Interface, meta *metav1.ObjectMeta) []string {
+	var chain []string
+	current := meta
+	for range maxOwnerDepth {
+		controller := metav1.GetControllerOfNoCopy(current)
+		if controller == nil {
+			break
+		}
+		chain = append(chain, controller.Kind+"/"+controller.Name)
+		current = controllerMeta(ctx, kubernetesClient, meta.Namespace, controller)
+		if current == nil {
+			break
+		}
+	}
+	return chain
+}
+
+// controllerMeta returns the metadata of a controller of a known kind, or nil when it cannot be read.
+func controllerMeta(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, controller *metav1.OwnerReference) *metav1.ObjectMeta {
+	var meta *metav1.ObjectMeta
+	switch controller.Kind {
+	case "ReplicaSet":
+		if object, err := kubernetesClient.AppsV1().ReplicaSets(namespace).Get(ctx, controller.Name, metav1.GetOptions{}); err == nil {
+			meta = &object.ObjectMeta
+		}
+	case "Deployment":
+		if object, err := kubernetesClient.AppsV1().Deployments(namespace).Get(ctx, controller.Name, metav1.GetOptions{}); err == nil {
+			meta = &object.ObjectMeta
+		}
+	case "StatefulSet":
+		if object, err := kubernetesClient.AppsV1().StatefulSets(namespace).Get(ctx, controller.Name, metav1.GetOptions{}); err == nil {
+			meta = &object.ObjectMeta
+		}
+	case "DaemonSet":
+		if object, err := kubernetesClient.AppsV1().DaemonSets(namespace).Get(ctx, controller.Name, metav1.GetOptions{}); err == nil {
+			meta = &object.ObjectMeta
+		}
+	case "Job":
+		if object, err := kubernetesClient.BatchV1().Jobs(namespace).Get(ctx, controller.Name, metav1.GetOptions{}); err == nil {
+			meta = &object.ObjectMeta
+		}
+	case "CronJob":
+		if object, err := kubernetesClient.BatchV1().CronJobs(namespace).Get(ctx, controller.Name, metav1.GetOptions{}); err == nil {
+			meta = &object.ObjectMeta
+		}
+	}
+	return meta
+}
+
+func describePod(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	pod, err := kubernetesClient.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &pod.ObjectMeta)
+	w.line("Node", valueOrNone(pod.Spec.NodeName))
+	w.line("Status", podStatusReason(pod))
+	w.line("IP", valueOrNone(pod.Status.PodIP))
+	w.line("QoS Class", valueOrNone(string(pod.Status.QOSClass)))
+	w.line("Service Account", valueOrNone(pod.Spec.ServiceAccountName))
+
+	statuses := map[string]corev1.ContainerStatus{}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		statuses[status.Name] = status
+	}
+	describeContainers := func(title string, containers []corev1.Container) {
+		if len(containers) == 0 {
+			return
+		}
+		w.section(title, func() {
+			for _, container := range containers {
+				w.section(container.Name, func() {
+					w.line("Image", container.Image)
+					if status, ok := statuses[container.Name]; ok {
+						w.line("State", containerState(status.State))
+						if status.LastTerminationState.Terminated != nil {
+							w.line("Last State", containerState(status.LastTerminationState))
+						}
+						w.line("Ready", status.Ready)
+						w.line("Restarts", status.RestartCount)
+					}
+					if len(container.Resources.Requests) > 0 {
+						w.line("Requests", formatResources(container.Resources.Requests))
+					}
+					if len(container.Resources.Limits) > 0 {
+						w.line("Limits", formatResources(container.Resources.Limits))
+					}
+				})
+			}
+		})
+	}
+	describeContainers("Init Containers", pod.Spec.InitContainers)
+	describeContainers("Containers", pod.Spec.Containers)
+
+	w.section("Conditions", func() {
+		for _, condition := range pod.Status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+	w.section("Volumes", func() {
+		for _, volume := range pod.Spec.Volumes {
+			w.line(volume.Name, volumeSource(volume))
+		}
+	})
+	return nil
+}
+
+func describeDeployment(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	deployment, err := kubernetesClient.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &deployment.ObjectMeta)
+	status := deployment.Status
+	w.line("Replicas", fmt.Sprintf("%d desired | %d updated | %d total | %d available | %d unavailable",
+		replicasOrDefault(deployment.Spec.Replicas), status.UpdatedReplicas, status.Replicas, status.AvailableReplicas, status.UnavailableReplicas))
+	w.line("Strategy", valueOrNone(string(deployment.Spec.Strategy.Type)))
+	w.line("Selector", metav1.FormatLabelSelector(deployment.Spec.Selector))
+	w.line("Paused", deployment.Spec.Paused)
+	_, rollout := deploymentRolloutStatus(deployment)
+	w.line("Rollout", rollout)
+	describePodTemplate(w, &deployment.Spec.Template.Spec)
+	w.section("Conditions", func() {
+		for _, condition := range status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+
+	replicaSets, err := kubernetesClient.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector)})
+	if err != nil {
+		return err
+	}
+	sort.Slice(replicaSets.Items, func(i, j int) bool {
+		return replicaSets.Items[i].Name < replicaSets.Items[j].Name
+	})
+	w.section("ReplicaSets", func() {
+		for _, replicaSet := range replicaSets.Items {
+			if !metav1.IsControlledBy(&replicaSet, deployment) {
+				continue
+			}
+			w.text("%s revision %s: %d/%d ready", replicaSet.Name, valueOrNone(replicaSet.Annotations["deployment.kubernetes.io/revision"]),
+				replicaSet.Status.ReadyReplicas, replicasOrDefault(replicaSet.Spec.Replicas))
+		}
+	})
+	return describePods(ctx, w, kubernetesClient, namespace, deployment.Spec.Selector)
+}
+
+func describeStatefulSet(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	statefulset, err := kubernetesClient.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &statefulset.ObjectMeta)
+	status := statefulset.Status
+	w.line("Replicas", fmt.Sprintf("%d desired | %d current | %d updated | %d ready",
+		replicasOrDefault(statefulset.Spec.Replicas), status.CurrentReplicas, status.UpdatedReplicas, status.ReadyReplicas))
+	w.line("Service Name", valueOrNone(statefulset.Spec.ServiceName))
+	w.line("Update Strategy", valueOrNone(string(statefulset.Spec.UpdateStrategy.Type)))
+	w.line("Selector", metav1.FormatLabelSelector(statefulset.Spec.Selector))
+	_, rollout := statefulsetRolloutStatus(statefulset)
+	w.line("Rollout", rollout)
+	describePodTemplate(w, &statefulset.Spec.Template.Spec)
+	claims, err := statefulsetClaims(ctx, statefulset, kubernetesClient.CoreV1().PersistentVolumeClaims(namespace), kubernetesClient.CoreV1().Pods(namespace))
+	if err != nil {
+		return err
+	}
+	w.section("Volume Claims", func() {
+		for _, claim := range claims {
+			description := fmt.Sprintf("%s, capacity %s, pod %s", claim.Phase, valueOrNone(claim.Capacity), valueOrNone(claim.Pod))
+			if claim.Orphaned {
+				description += ", orphaned"
+			}
+			w.line(claim.Name, description)
+		}
+	})
+	w.section("Conditions", func() {
+		for _, condition := range status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+	return describePods(ctx, w, kubernetesClient, namespace, statefulset.Spec.Selector)
+}
+
+func describeDaemonSet(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	daemonset, err := kubernetesClient.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &daemonset.ObjectMeta)
+	status := daemonset.Status
+	w.line("Pods", fmt.Sprintf("%d desired | %d current | %d updated | %d ready | %d available | %d misscheduled",
+		status.DesiredNumberScheduled, status.CurrentNumberScheduled, status.UpdatedNumberScheduled, status.NumberReady, status.NumberAvailable, status.NumberMisscheduled))
+	w.line("Update Strategy", valueOrNone(string(daemonset.Spec.UpdateStrategy.Type)))
+	w.line("Selector", metav1.FormatLabelSelector(daemonset.Spec.Selector))
+	w.line("Node Selector", formatMap(daemonset.Spec.Template.Spec.NodeSelector))
+	_, rollout := daemonsetRolloutStatus(daemonset)
+	w.line("Rollout", rollout)
+	describePodTemplate(w, &daemonset.Spec.Template.Spec)
+	w.section("Conditions", func() {
+		for _, condition := range status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+	return describePods(ctx, w, kubernetesClient, namespace, daemonset.Spec.Selector)
+}
+
+func describeReplicaSet(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	replicaSet, err := kubernetesClient.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &replicaSet.ObjectMeta)
+	status := replicaSet.Status
+	w.line("Replicas", fmt.Sprintf("%d desired | %d current | %d ready | %d available",
+		replicasOrDefault(replicaSet.Spec.Replicas), status.Replicas, status.ReadyReplicas, status.AvailableReplicas))
+	w.line("Selector", metav1.FormatLabelSelector(replicaSet.Spec.Selector))
+	describePodTemplate(w, &replicaSet.Spec.Template.Spec)
+	w.section("Conditions", func() {
+		for _, condition := range status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+	return describePods(ctx, w, kubernetesClient, namespace, replicaSet.Spec.Selector)
+}
+
+func describeJob(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	job, err := kubernetesClient.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &job.ObjectMeta)
+	status := job.Status
+	w.line("Completions", fmt.Sprintf("%d of %d", status.Succeeded, replicasOrDefault(job.Spec.Completions)))
+	w.line("Parallelism", replicasOrDefault(job.Spec.Parallelism))
+	w.line("Pods", fmt.Sprintf("%d active | %d succeeded | %d failed", status.Active, status.Succeeded, status.Failed))
+	if job.Spec.BackoffLimit != nil {
+		w.line("Backoff Limit", *job.Spec.BackoffLimit)
+	}
+	w.line("Suspended", job.Spec.Suspend != nil && *job.Spec.Suspend)
+	if status.StartTime != nil {
+		w.line("Start Time", formatTime(status.StartTime.Time))
+	}
+	if status.CompletionTime != nil {
+		w.line("Completion Time", formatTime(status.CompletionTime.Time))
+	}
+	describePodTemplate(w, &job.Spec.Template.Spec)
+	w.section("Conditions", func() {
+		for _, condition := range status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+	return describePods(ctx, w, kubernetesClient, namespace, job.Spec.Selector)
+}
+
+func describeCronJob(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	cronJob, err := kubernetesClient.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &cronJob.ObjectMeta)
+	w.line("Schedule", cronJob.Spec.Schedule)
+	if cronJob.Spec.TimeZone != nil {
+		w.line("Time Zone", *cronJob.Spec.TimeZone)
+	}
+	w.line("Concurrency Policy", valueOrNone(string(cronJob.Spec.ConcurrencyPolicy)))
+	w.line("Suspended", cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend)
+	if cronJob.Status.LastScheduleTime != nil {
+		w.line("Last Schedule Time", formatTime(cronJob.Status.LastScheduleTime.Time))
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		w.line("Last Successful Time", formatTime(cronJob.Status.LastSuccessfulTime.Time))
+	}
+	describePodTemplate(w, &cronJob.Spec.JobTemplate.Spec.Template.Spec)
+
+	jobs, err := kubernetesClient.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sort.Slice(jobs.Items, func(i, j int) bool {
+		return jobs.Items[i].Name < jobs.Items[j].Name
+	})
+	w.section("Jobs", func() {
+		for _, job := range jobs.Items {
+			if !metav1.IsControlledBy(&job, cronJob) {
+				continue
+			}
+			state := "Running"
+			if finished := jobFinishedStatus(&job); finished != "" {
+				state = string(finished)
+			}
+			w.text("%s: %s, %d succeeded, %d failed", job.Name, state, job.Status.Succeeded, job.Status.Failed)
+		}
+	})
+	return nil
+}
+
+func describeService(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	service, err := kubernetesClient.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &service.ObjectMeta)
+	w.line("Type", service.Spec.Type)
+	w.line("Selector", formatMap(service.Spec.Selector))
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		w.line("External Name", service.Spec.ExternalName)
+	} else {
+		w.line("Cluster IP", valueOrNone(service.Spec.ClusterIP))
+	}
+	if len(service.Spec.ExternalIPs) > 0 {
+		w.line("External IPs", strings.Join(service.Spec.ExternalIPs, ", "))
+	}
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		w.line("LoadBalancer Ingress", valueOrNone(ingress.IP+ingress.Hostname))
+	}
+	w.section("Ports", func() {
+		for _, port := range service.Spec.Ports {
+			description := fmt.Sprintf("%d/%s -> %s", port.Port, port.Protocol, port.TargetPort.String())
+			if port.NodePort != 0 {
+				description += fmt.Sprintf(", node port %d", port.NodePort)
+			}
+			w.line(valueOrNone(port.Name), description)
+		}
+	})
+
+	endpoints, err := resolveServiceEndpoints(ctx, service, kubernetesClient.DiscoveryV1().EndpointSlices(namespace), kubernetesClient.CoreV1().Pods(namespace))
+	if err != nil {
+		return err
+	}
+	w.section("Endpoints", func() {
+		for _, endpoint := range endpoints.Endpoints {
+			state := "ready"
+			if !endpoint.Ready {
+				state = "not ready"
+			}
+			if endpoint.Terminating {
+				state += ", terminating"
+			}
+			w.text("%s (%s) pod %s: %s", endpoint.Address, strings.Join(endpoint.Ports, ", "), valueOrNone(endpoint.Pod), state)
+		}
+	})
+	if len(endpoints.Warnings) > 0 {
+		w.section("Warnings", func() {
+			for _, warning := range endpoints.Warnings {
+				w.text("%s", warning)
+			}
+		})
+	}
+	return nil
+}
+
+func describeConfigMap(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	configMap, err := kubernetesClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &configMap.ObjectMeta)
+	w.line("Immutable", configMap.Immutable != nil && *configMap.Immutable)
+	w.section("Data", func() {
+		for _, key := range sortedKeys(configMap.Data) {
+			w.line(key, fmt.Sprintf("%d bytes", len(configMap.Data[key])))
+		}
+		for _, key := range sortedKeys(configMap.BinaryData) {
+			w.line(key, fmt.Sprintf("%d bytes (binary)", len(configMap.BinaryData[key])))
+		}
+	})
+	return describeConsumers(ctx, w, kubernetesClient, namespace, configMapKind, name)
+}
+
+func describeSecret(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	secret, err := kubernetesClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &secret.ObjectMeta)
+	w.line("Type", secret.Type)
+	w.line("Immutable", secret.Immutable != nil && *secret.Immutable)
+	// Like kubectl describe, only the sizes of the values are shown.
+	w.section("Data", func() {
+		for _, key := range sortedKeys(secret.Data) {
+			w.line(key, fmt.Sprintf("%d bytes", len(secret.Data[key])))
+		}
+	})
+	return describeConsumers(ctx, w, kubernetesClient, namespace, secretKind, name)
+}
+
+func describeConsumers(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, kind string, name string) error {
+	consumers, err := findConsumers(ctx, kubernetesClient, namespace, kind, name)
+	if err != nil {
+		return err
+	}
+	w.section("Used By", func() {
+		for _, consumer := range consumers {
+			w.line(consumer.Kind+"/"+consumer.Name, strings.Join(consumer.Usages, ", "))
+		}
+	})
+	return nil
+}
+
+func describeServiceAccount(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	serviceAccount, err := kubernetesClient.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &serviceAccount.ObjectMeta)
+	w.line("Automount Token", serviceAccount.AutomountServiceAccountToken == nil || *serviceAccount.AutomountServiceAccountToken)
+	w.section("Image Pull Secrets", func() {
+		for _, secret := range serviceAccount.ImagePullSecrets {
+			w.text("%s", secret.Name)
+		}
+	})
+	w.section("Mountable Secrets", func() {
+		for _, secret := range serviceAccount.Secrets {
+			w.text("%s", secret.Name)
+		}
+	})
+
+	// Long-lived tokens are secrets of type service-account-token annotated with the account name.
+	secrets, err := kubernetesClient.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=" + string(corev1.SecretTypeServiceAccountToken)})
+	if err != nil {
+		return err
+	}
+	w.section("Tokens", func() {
+		for _, secret := range secrets.Items {
+			if secret.Annotations[corev1.ServiceAccountNameKey] == name {
+				w.text("%s", secret.Name)
+			}
+		}
+	})
+	pods, err := kubernetesClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{FieldSelector: "spec.serviceAccountName=" + name})
+	if err != nil {
+		return err
+	}
+	describePodList(w, pods.Items, false)
+	return nil
+}
+
+func describeResourceQuota(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	quota, err := kubernetesClient.CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &quota.ObjectMeta)
+	usage := usageOfQuota(quota, nil)
+	w.line("Scopes", valueOrNone(strings.Join(usage.Scopes, ", ")))
+	w.section("Resources", func() {
+		for _, entry := range usage.Resources {
+			w.line(entry.Resource, fmt.Sprintf("%s used of %s (%.1f%%)", entry.Used, entry.Hard, entry.Percent))
+		}
+	})
+	return nil
+}
+
+func describeLimitRange(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	limitRange, err := kubernetesClient.CoreV1().LimitRanges(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &limitRange.ObjectMeta)
+	for _, item := range limitRange.Spec.Limits {
+		w.section(string(item.Type), func() {
+			for _, pair := range []struct {
+				label  string
+				values corev1.ResourceList
+			}{
+				{"Min", item.Min},
+				{"Max", item.Max},
+				{"Default Request", item.DefaultRequest},
+				{"Default Limit", item.Default},
+				{"Max Limit/Request Ratio", item.MaxLimitRequestRatio},
+			} {
+				if len(pair.values) > 0 {
+					w.line(pair.label, formatResources(pair.values))
+				}
+			}
+		})
+	}
+	return nil
+}
+
+func describePodDisruptionBudget(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	budget, err := kubernetesClient.PolicyV1().PodDisruptionBudgets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	status, err := disruptionBudgetStatus(ctx, budget, kubernetesClient)
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &budget.ObjectMeta)
+	w.line("Selector", status.Selector)
+	if status.MinAvailable != "" {
+		w.line("Min Available", status.MinAvailable)
+	}
+	if status.MaxUnavailable != "" {
+		w.line("Max Unavailable", status.MaxUnavailable)
+	}
+	w.line("Allowed Disruptions", budget.Status.DisruptionsAllowed)
+	w.line("Current Healthy", budget.Status.CurrentHealthy)
+	w.line("Desired Healthy", budget.Status.DesiredHealthy)
+	w.line("Expected Pods", budget.Status.ExpectedPods)
+	w.section("Conditions", func() {
+		for _, condition := range budget.Status.Conditions {
+			w.text("%s", formatCondition(condition.Type, corev1.ConditionStatus(condition.Status), condition.Reason, condition.Message))
+		}
+	})
+	w.section("Problems", func() {
+		for _, problem := range status.Problems {
+			w.text("%s", problem)
+		}
+	})
+	return describePods(ctx, w, kubernetesClient, namespace, budget.Spec.Selector)
+}
+
+func describeNamespace(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, name string) error {
+	namespace, err := kubernetesClient.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &namespace.ObjectMeta)
+	w.line("Status", valueOrNone(string(namespace.Status.Phase)))
+	var finalizers []string
+	for _, finalizer := range namespace.Spec.Finalizers {
+		finalizers = append(finalizers, string(finalizer))
+	}
+	w.line("Finalizers", valueOrNone(strings.Join(finalizers, ", ")))
+	w.section("Conditions", func() {
+		for _, condition := range namespace.Status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+
+	quotas, err := kubernetesClient.CoreV1().ResourceQuotas(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	w.section("Resource Quotas", func() {
+		for _, quota := range quotas.Items {
+			w.line(quota.Name, formatResources(quota.Status.Hard))
+		}
+	})
+	return nil
+}
+
+func describeNode(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, name string) error {
+	node, err := kubernetesClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	pods, err := kubernetesClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: "spec.nodeName=" + name})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &node.ObjectMeta)
+	w.line("Unschedulable", node.Spec.Unschedulable)
+	var taints []string
+	for _, taint := range node.Spec.Taints {
+		taints = append(taints, taint.ToString())
+	}
+	w.line("Taints", valueOrNone(strings.Join(taints, ", ")))
+	var addresses []string
+	for _, address := range node.Status.Addresses {
+		addresses = append(addresses, fmt.Sprintf("%s=%s", address.Type, address.Address))
+	}
+	w.line("Addresses", valueOrNone(strings.Join(addresses, ", ")))
+	info := node.Status.NodeInfo
+	w.line("Kubelet Version", info.KubeletVersion)
+	w.line("OS Image", info.OSImage)
+	w.line("Container Runtime", info.ContainerRuntimeVersion)
+	w.line("Capacity", formatResources(node.Status.Capacity))
+	w.line("Allocatable", formatResources(node.Status.Allocatable))
+	w.section("Conditions", func() {
+		for _, condition := range node.Status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+	describePodList(w, pods.Items, true)
+	return nil
+}
+
+func describePersistentVolumeClaim(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	pvc, err := kubernetesClient.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &pvc.ObjectMeta)
+	w.line("Status", pvc.Status.Phase)
+	w.line("Volume", valueOrNone(pvc.Spec.VolumeName))
+	w.line("Storage Class", valueOrNone(ptr.Deref(pvc.Spec.StorageClassName, "")))
+	w.line("Requested", formatResources(pvc.Spec.Resources.Requests))
+	w.line("Capacity", formatResources(pvc.Status.Capacity))
+	w.line("Access Modes", valueOrNone(formatAccessModes(pvc.Spec.AccessModes)))
+	if pvc.Spec.VolumeMode != nil {
+		w.line("Volume Mode", *pvc.Spec.VolumeMode)
+	}
+	w.section("Conditions", func() {
+		for _, condition := range pvc.Status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+
+	pods, err := kubernetesClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	w.section("Used By", func() {
+		for _, pod := range pods.Items {
+			for _, volume := range pod.Spec.Volumes {
+				if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == name {
+					w.text("%s", pod.Name)
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func describePersistentVolume(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, name string) error {
+	pv, err := kubernetesClient.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &pv.ObjectMeta)
+	w.line("Status", pv.Status.Phase)
+	if pv.Status.Message != "" {
+		w.line("Message", pv.Status.Message)
+	}
+	claim := "<none>"
+	if pv.Spec.ClaimRef != nil {
+		claim = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+	}
+	w.line("Claim", claim)
+	w.line("Storage Class", valueOrNone(pv.Spec.StorageClassName))
+	w.line("Reclaim Policy", pv.Spec.PersistentVolumeReclaimPolicy)
+	w.line("Capacity", formatResources(pv.Spec.Capacity))
+	w.line("Access Modes", valueOrNone(formatAccessModes(pv.Spec.AccessModes)))
+	source := pv.Spec.PersistentVolumeSource
+	switch {
+	case source.CSI != nil:
+		w.line("Source", "CSI "+source.CSI.Driver+" "+source.CSI.VolumeHandle)
+	case source.HostPath != nil:
+		w.line("Source", "HostPath "+source.HostPath.Path)
+	case source.Local != nil:
+		w.line("Source", "Local "+source.Local.Path)
+	case source.NFS != nil:
+		w.line("Source", "NFS "+source.NFS.Server+":"+source.NFS.Path)
+	}
+	return nil
+}
+
+func describeStorageClass(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, name string) error {
+	storageClass, err := kubernetesClient.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &storageClass.ObjectMeta)
+	w.line("Default", storageClass.Annotations[defaultStorageClassAnnotation] == "true")
+	w.line("Provisioner", storageClass.Provisioner)
+	if storageClass.ReclaimPolicy != nil {
+		w.line("Reclaim Policy", *storageClass.ReclaimPolicy)
+	}
+	if storageClass.VolumeBindingMode != nil {
+		w.line("Volume Binding Mode", *storageClass.VolumeBindingMode)
+	}
+	w.line("Allow Volume Expansion", storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion)
+	w.line("Parameters", formatMap(storageClass.Parameters))
+	return nil
+}
+
+func describeIngress(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	ingress, err := kubernetesClient.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &ingress.ObjectMeta)
+	routes, err := resolveIngressRoutes(ctx, ingress, kubernetesClient)
+	if err != nil {
+		return err
+	}
+	w.line("Ingress Class", valueOrNone(routes.IngressClass))
+	var addresses []string
+	for _, address := range ingress.Status.LoadBalancer.Ingress {
+		addresses = append(addresses, address.IP+address.Hostname)
+	}
+	w.line("Address", valueOrNone(strings.Join(addresses, ", ")))
+	w.section("Routes", func() {
+		for _, route := range routes.Routes {
+			w.text("%s%s -> %s (%d/%d endpoints ready)", route.Host, route.Path, route.Backend, route.ReadyEndpoints, route.Endpoints)
+			for _, problem := range route.Problems {
+				w.text("  ! %s", problem)
+			}
+		}
+	})
+	w.section("TLS", func() {
+		for _, tls := range routes.TLS {
+			w.line(strings.Join(tls.Hosts, ", "), valueOrNone(tls.Secret))
+			for _, problem := range tls.Problems {
+				w.text("  ! %s", problem)
+			}
+		}
+	})
+	return nil
+}
+
+func describeIngressClass(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, name string) error {
+	ingressClass, err := kubernetesClient.NetworkingV1().IngressClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &ingressClass.ObjectMeta)
+	w.line("Default", ingressClass.Annotations[networkingv1.AnnotationIsDefaultIngressClass] == "true")
+	w.line("Controller", ingressClass.Spec.Controller)
+	if parameters := ingressClass.Spec.Parameters; parameters != nil {
+		w.line("Parameters", parameters.Kind+"/"+parameters.Name)
+	}
+	return nil
+}
+
+func describeNetworkPolicy(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	networkPolicy, err := kubernetesClient.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &networkPolicy.ObjectMeta)
+	w.line("Pod Selector", valueOrNone(metav1.FormatLabelSelector(&networkPolicy.Spec.PodSelector)))
+	var policyTypes []string
+	for _, policyType := range []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress} {
+		if hasPolicyType(networkPolicy, policyType) {
+			policyTypes = append(policyTypes, string(policyType))
+		}
+	}
+	w.line("Policy Types", strings.Join(policyTypes, ", "))
+	if hasPolicyType(networkPolicy, networkingv1.PolicyTypeIngress) {
+		w.section("Ingress Rules", func() {
+			for i, rule := range networkPolicy.Spec.Ingress {
+				w.text("%d. from %s on %s", i+1, formatPeers(rule.From), formatPolicyPorts(rule.Ports))
+			}
+		})
+	}
+	if hasPolicyType(networkPolicy, networkingv1.PolicyTypeEgress) {
+		w.section("Egress Rules", func() {
+			for i, rule := range networkPolicy.Spec.Egress {
+				w.text("%d. to %s on %s", i+1, formatPeers(rule.To), formatPolicyPorts(rule.Ports))
+			}
+		})
+	}
+	return nil
+}
+
+func describeHPA(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, name string) error {
+	hpa, err := kubernetesClient.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	describeMeta(ctx, w, kubernetesClient, &hpa.ObjectMeta)
+	w.line("Reference", hpa.Spec.ScaleTargetRef.Kind+"/"+hpa.Spec.ScaleTargetRef.Name)
+	w.line("Min Replicas", fmt.Sprint(replicasOrDefault(hpa.Spec.MinReplicas)))
+	w.line("Max Replicas", fmt.Sprint(hpa.Spec.MaxReplicas))
+	w.line("Replicas", fmt.Sprintf("%d current / %d desired", hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas))
+	if hpa.Status.LastScaleTime != nil {
+		w.line("Last Scale Time", formatTime(hpa.Status.LastScaleTime.Time))
+	}
+	w.section("Metrics", func() {
+		for _, metric := range hpa.Spec.Metrics {
+			described := describeHPAMetric(metric, hpa.Status.CurrentMetrics)
+			w.line(described.Type+" "+described.Name, described.Current+" / "+described.Target)
+		}
+	})
+	w.section("Conditions", func() {
+		for _, condition := range hpa.Status.Conditions {
+			w.text("%s", formatCondition(string(condition.Type), condition.Status, condition.Reason, condition.Message))
+		}
+	})
+	return nil
+}
+
+// describePodTemplate writes the containers of a pod template with their images.
+func describePodTemplate(w *describeWriter, podSpec *corev1.PodSpec) {
+	w.section("Pod Template", func() {
+		for _, container := range podSpec.InitContainers {
+			w.line(container.Name+" (init)", container.Image)
+		}
+		for _, container := range podSpec.Containers {
+			w.line(container.Name, container.Image)
+		}
+	})
+}
+
+// describePods writes the pods matching selector.
+func describePods(ctx context.Context, w *describeWriter, kubernetesClient kubernetes.Interface, namespace string, selector *metav1.LabelSelector) error {
+	if selector == nil {
+		return nil
+	}
+	pods, err := kubernetesClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: metav1.FormatLabelSelector(selector)})
+	if err != nil {
+		return err
+	}
+	describePodList(w, pods.Items, false)
+	return nil
+}
+
+func describePodList(w *describeWriter, pods []corev1.Pod, withNamespace bool) {
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	w.section("Pods", func() {
+		for _, pod := range pods {
+			ready, restarts := 0, int32(0)
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.Ready {
+					ready++
+				}
+				restarts += status.RestartCount
+			}
+			name := pod.Name
+			if withNamespace {
+				name = pod.Namespace + "/" + pod.Name
+			}
+			w.text("%s: %s, %d/%d ready, %d restarts, node %s", name, podStatusReason(&pod), ready, len(pod.Spec.Containers), restarts, valueOrNone(pod.Spec.NodeName))
+		}
+	})
+}
+
+// podStatusReason returns the pod phase, or the reason a container is waiting or terminated, like the STATUS column of kubectl get pods.
+func podStatusReason(pod *corev1.Pod) string {
+	if pod.DeletionTimestamp != nil {
+		return "Terminating"
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			return status.State.Waiting.Reason
+		}
+		if status.State.Terminated != nil && status.State.Terminated.Reason != "" && pod.Status.Phase != corev1.PodSucceeded {
+			return status.State.Terminated.Reason
+		}
+	}
+	if pod.Status.Reason != "" {
+		return pod.Status.Reason
+	}
+	return string(pod.Status.Phase)
+}
+
+func containerState(state corev1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return "Running since " + formatTime(state.Running.StartedAt.Time)
+	case state.Waiting != nil:
+		return "Waiting (" + valueOrNone(state.Waiting.Reason) + ")"
+	case state.Terminated != nil:
+		return fmt.Sprintf("Terminated (%s, exit code %d) at %s", valueOrNone(state.Terminated.Reason), state.Terminated.ExitCode, formatTime(state.Terminated.FinishedAt.Time))
+	}
+	return "<none>"
+}
+
+// volumeSource names the kind of a volume and what it refers to.
+func volumeSource(volume corev1.Volume) string {
+	source := volume.VolumeSource
+	switch {
+	case source.ConfigMap != nil:
+		return "ConfigMap " + source.ConfigMap.Name
+	case source.Secret != nil:
+		return "Secret " + source.Secret.SecretName
+	case source.PersistentVolumeClaim != nil:
+		return "PersistentVolumeClaim " + source.PersistentVolumeClaim.ClaimName
+	case source.EmptyDir != nil:
+		return "EmptyDir"
+	case source.HostPath != nil:
+		return "HostPath " + source.HostPath.Path
+	case source.Projected != nil:
+		return "Projected"
+	case source.DownwardAPI != nil:
+		return "DownwardAPI"
+	case source.Ephemeral != nil:
+		return "Ephemeral"
+	}
+	return "Other"
+}
+
+func formatCondition(conditionType string, status corev1.ConditionStatus, reason string, message string) string {
+	condition := conditionType + "=" + string(status)
+	if reason != "" {
+		condition += " (" + reason + ")"
+	}
+	if message != "" {
+		condition += ": " + message
+	}
+	return condition
+}
+
+func formatResources(resources corev1.ResourceList) string {
+	var values []string
+	for _, name := range sortedKeys(resources) {
+		quantity := resources[name]
+		values = append(values, string(name)+"="+quantity.String())
+	}
+	return valueOrNone(strings.Join(values, ", "))
+}
+
+func formatMap(values map[string]string) string {
+	var pairs []string
+	for _, key := range sortedKeys(values) {
+		pairs = append(pairs, key+"="+values[key])
+	}
+	return valueOrNone(strings.Join(pairs, ", "))
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "<none>"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatPeers(peers []networkingv1.NetworkPolicyPeer) string {
+	if len(peers) == 0 {
+		return "anywhere"
+	}
+	var values []string
+	for _, peer := range peers {
+		switch {
+		case peer.IPBlock != nil:
+			value := "ipBlock " + peer.IPBlock.CIDR
+			if len(peer.IPBlock.Except) > 0 {
+				value += " except " + strings.Join(peer.IPBlock.Except, ", ")
+			}
+			values = append(values, value)
+		case peer.NamespaceSelector != nil && peer.PodSelector != nil:
+			values = append(values, fmt.Sprintf("pods {%s} in namespaces {%s}", metav1.FormatLabelSelector(peer.PodSelector), metav1.FormatLabelSelector(peer.NamespaceSelector)))
+		case peer.NamespaceSelector != nil:
+			values = append(values, fmt.Sprintf("namespaces {%s}", metav1.FormatLabelSelector(peer.NamespaceSelector)))
+		case peer.PodSelector != nil:
+			values = append(values, fmt.Sprintf("pods {%s}", metav1.FormatLabelSelector(peer.PodSelector)))
+		}
+	}
+	return strings.Join(values, " or ")
+}
+
+func formatPolicyPorts(ports []networkingv1.NetworkPolicyPort) string {
+	if len(ports) == 0 {
+		return "all ports"
+	}
+	var values []string
+	for _, port := range ports {
+		protocol := corev1.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+		value := "all"
+		if port.Port != nil {
+			value = port.Port.String()
+		}
+		if port.EndPort != nil {
+			value += fmt.Sprintf("-%d", *port.EndPort)
+		}
+		values = append(values, value+"/"+string(protocol))
+	}
+	return strings.Join(values, ", ")
+}
+
+func formatAccessModes(modes []corev1.PersistentVolumeAccessMode) string {
+	var values []string
+	for _, mode := range modes {
+		values = append(values, string(mode))
+	}
+	return strings.Join(values, ", ")
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
+
+func sortedKeys[K ~string, V any](values map[K]V) []K {
+	keys := make([]K, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,256 @@
+package tools
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// eventsAPIs are the APIs events can be read from.
+var eventsAPIs = []string{"core", "events.k8s.io"}
+
+// eventTypes are the event types Kubernetes components report.
+var eventTypes = []string{corev1.EventTypeNormal, corev1.EventTypeWarning}
+
+// recentEventLimit is the number of timeline entries returned with a get that includes events.
+const recentEventLimit = 10
+
+// eventFilter selects the events to return. Empty fields match everything.
+type eventFilter struct {
+	API    string
+	Kind   string
+	Name   string
+	Type   string
+	Reason string
+	Since  time.Duration
+}
+
+// eventEntry is one line of an event timeline. Repeated events of a series are collapsed into a single
+// entry with their total count and the first and last time they were seen.
+type eventEntry struct {
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+	Type      string    `json:"type"`
+	Reason    string    `json:"reason"`
+	Object    string    `json:"object"`
+	Namespace string    `json:"namespace,omitempty"`
+	Message   string    `json:"message"`
+	Count     int32     `json:"count"`
+	Source    string    `json:"source,omitempty"`
+}
+
+// objectWithEvents is the result of a get that includes the object's recent events.
+type objectWithEvents struct {
+	Object any          `json:"object"`
+	Events []eventEntry `json:"events"`
+}
+
+func eventsMCPResponse(ctx context.Context, namespace string, action string, filter eventFilter, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "list":
+		timeline, err := eventTimeline(ctx, kubernetesClient, namespace, filter)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(timeline), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// withRecentEvents wraps the result of a get with the most recent events of the object it returned.
+func withRecentEvents(ctx context.Context, result *mcp.CallToolResult, kubernetesClient kubernetes.Interface, namespace string, kind string, name string) (*mcp.CallToolResult, error) {
+	timeline, err := eventTimeline(ctx, kubernetesClient, namespace, eventFilter{Kind: kind, Name: name})
+	if err != nil {
+		return mcp.NewToolResultError("Failed to list events: " + err.Error()), nil
+	}
+	if len(timeline) > recentEventLimit {
+		timeline = timeline[len(timeline)-recentEventLimit:]
+	}
+
+	object := result.StructuredContent
+	// Most get actions return the object already marshalled to JSON.
+	if raw, ok := object.([]byte); ok {
+		object = rawJSON(raw)
+	}
+	return mcp.NewToolResultStructuredOnly(objectWithEvents{Object: object, Events: timeline}), nil
+}
+
+// rawJSON embeds already marshalled JSON as is.
+type rawJSON []byte
+
+func (r rawJSON) MarshalJSON() ([]byte, error) {
+	return r, nil
+}
+
+// eventTimeline lists the events matching filter in namespace, or in all namespaces when it is empty,
+// and returns them collapsed into series, oldest first.
+func eventTimeline(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, filter eventFilter) ([]eventEntry, error) {
+	var entries []eventEntry
+	var err error
+	if filter.API == "events.k8s.io" {
+		entries, err = eventsAPIEntries(ctx, kubernetesClient, namespace, filter)
+	} else {
+		entries, err = coreEventEntries(ctx, kubernetesClient, namespace, filter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := []eventEntry{}
+	series := map[string]int{}
+	for _, entry := range entries {
+		key := strings.Join([]string{entry.Namespace, entry.Object, entry.Type, entry.Reason, entry.Message}, "\x00")
+		i, seen := series[key]
+		if !seen {
+			series[key] = len(timeline)
+			timeline = append(timeline, entry)
+			continue
+		}
+		collapsed := &timeline[i]
+		collapsed.Count += entry.Count
+		if entry.FirstSeen.Before(collapsed.FirstSeen) {
+			collapsed.FirstSeen = entry.FirstSeen
+		}
+		if entry.LastSeen.After(collapsed.LastSeen) {
+			collapsed.LastSeen = entry.LastSeen
+		}
+	}
+
+	if filter.Since > 0 {
+		cutoff := time.Now().Add(-filter.Since)
+		recent := []eventEntry{}
+		for _, entry := range timeline {
+			if !entry.LastSeen.Before(cutoff) {
+				recent = append(recent, entry)
+			}
+		}
+		timeline = recent
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		if !timeline[i].LastSeen.Equal(timeline[j].LastSeen) {
+			return timeline[i].LastSeen.Before(timeline[j].LastSeen)
+		}
+		return timeline[i].FirstSeen.Before(timeline[j].FirstSeen)
+	})
+	return timeline, nil
+}
+
+// eventFieldSelector builds the server-side part of filter. objectField is the prefix of the
+// referenced object fields, which differs between the core and events.k8s.io APIs.
+func eventFieldSelector(filter eventFilter, objectField string) string {
+	selector := fields.Set{}
+	for field, value := range map[string]string{
+		objectField + ".kind": filter.Kind,
+		objectField + ".name": filter.Name,
+		"type":                filter.Type,
+		"reason":              filter.Reason,
+	} {
+		if value != "" {
+			selector[field] = value
+		}
+	}
+	return fields.SelectorFromSet(selector).String()
+}
+
+func coreEventEntries(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, filter eventFilter) ([]eventEntry, error) {
+	eventList, err := kubernetesClient.CoreV1().Events(namespace).List(
+		ctx,
+		metav1.ListOptions{FieldSelector: eventFieldSelector(filter, "involvedObject")},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []eventEntry
+	for _, event := range eventList.Items {
+		entry := eventEntry{
+			FirstSeen: event.FirstTimestamp.Time,
+			LastSeen:  event.LastTimestamp.Time,
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Object:    event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			Namespace: event.Namespace,
+			Message:   event.Message,
+			Count:     event.Count,
+			Source:    event.Source.Component,
+		}
+		// Events recorded through the new API only set eventTime and series on the core representation.
+		if entry.FirstSeen.IsZero() {
+			entry.FirstSeen = event.EventTime.Time
+		}
+		if event.Series != nil {
+			entry.Count = event.Series.Count
+			entry.LastSeen = event.Series.LastObservedTime.Time
+		}
+		if entry.LastSeen.IsZero() {
+			entry.LastSeen = entry.FirstSeen
+		}
+		if entry.Count == 0 {
+			entry.Count = 1
+		}
+		if entry.Source == "" {
+			entry.Source = event.ReportingController
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func eventsAPIEntries(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, filter eventFilter) ([]eventEntry, error) {
+	eventList, err := kubernetesClient.EventsV1().Events(namespace).List(
+		ctx,
+		metav1.ListOptions{FieldSelector: eventFieldSelector(filter, "regarding")},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []eventEntry
+	for _, event := range eventList.Items {
+		entries = append(entries, eventsAPIEntry(&event))
+	}
+	return entries, nil
+}
+
+func eventsAPIEntry(event *eventsv1.Event) eventEntry {
+	entry := eventEntry{
+		FirstSeen: event.EventTime.Time,
+		LastSeen:  event.EventTime.Time,
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Object:    event.Regarding.Kind + "/" + event.Regarding.Name,
+		Namespace: event.Namespace,
+		Message:   event.Note,
+		Count:     1,
+		Source:    event.ReportingController,
+	}
+	// Events converted from the core API only carry the deprecated timestamps and count.
+	if entry.FirstSeen.IsZero() {
+		entry.FirstSeen = event.DeprecatedFirstTimestamp.Time
+		entry.LastSeen = event.DeprecatedLastTimestamp.Time
+	}
+	if event.DeprecatedCount > 0 {
+		entry.Count = event.DeprecatedCount
+	}
+	if event.Series != nil {
+		entry.Count = event.Series.Count
+		entry.LastSeen = event.Series.LastObservedTime.Time
+	}
+	if entry.LastSeen.IsZero() {
+		entry.LastSeen = entry.FirstSeen
+	}
+	if entry.Source == "" {
+		entry.Source = event.DeprecatedSource.Component
+	}
+	return entry
+}
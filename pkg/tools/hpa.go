@@ -0,0 +1,409 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	v2 "k8s.io/client-go/kubernetes/typed/autoscaling/v2"
+)
+
+// hpaTolerance is the default relative distance from the target within which the autoscaler does not scale.
+const hpaTolerance = 0.1
+
+// hpaExplanation reports the state of an autoscaler and, in plain language, why it is or is not scaling its target.
+type hpaExplanation struct {
+	Name            string                                           `json:"name"`
+	Target          string                                           `json:"target"`
+	MinReplicas     int32                                            `json:"minReplicas"`
+	MaxReplicas     int32                                            `json:"maxReplicas"`
+	CurrentReplicas int32                                            `json:"currentReplicas"`
+	DesiredReplicas int32                                            `json:"desiredReplicas"`
+	LastScaleTime   *metav1.Time                                     `json:"lastScaleTime,omitempty"`
+	Metrics         []hpaMetric                                      `json:"metrics"`
+	Conditions      []autoscalingv2.HorizontalPodAutoscalerCondition `json:"conditions,omitempty"`
+	Explanation     []string                                         `json:"explanation"`
+}
+
+type hpaMetric struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Current string `json:"current"`
+	Target  string `json:"target"`
+	// Ratio is current divided by target. It is only meaningful when known is set, and is 0 for an idle metric.
+	Ratio float64 `json:"ratio,omitempty"`
+	// known is set when both the current value and a non-zero target are available.
+	known bool
+}
+
+func hpaMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, hpaInterface v2.HorizontalPodAutoscalerInterface, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		hpaJson := []byte(resourceSpec)
+		var hpaSpec autoscalingv2.HorizontalPodAutoscaler
+		if err := json.Unmarshal(hpaJson, &hpaSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		hpa, err := hpaInterface.Create(
+			ctx,
+			&autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      hpaSpec.Labels,
+					Annotations: hpaSpec.Annotations,
+				},
+				Spec: hpaSpec.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdHPASpec, err := json.Marshal(hpa)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created hpa: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdHPASpec), nil
+	case "delete":
+		err := hpaInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted hpa " + name + " in namespace " + namespace), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var hpaSpec autoscalingv2.HorizontalPodAutoscaler
+		if err := json.Unmarshal([]byte(resourceSpec), &hpaSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		hpa, err := hpaInterface.Update(
+			ctx,
+			&autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      hpaSpec.Labels,
+					Annotations: hpaSpec.Annotations,
+				},
+				Spec: hpaSpec.Spec,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedHPASpec, err := json.Marshal(hpa)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated hpa: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedHPASpec), nil
+	case "get":
+		hpa, err := hpaInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		hpaSpec, err := json.Marshal(hpa)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal hpa: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(hpaSpec), nil
+	case "list":
+		hpas, err := hpaInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var hpaNames []string
+		for _, hpa := range hpas.Items {
+			hpaNames = append(hpaNames, hpa.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(hpaNames), nil
+	case "explain":
+		hpa, err := hpaInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(explainHPA(ctx, hpa, kubernetesClient)), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// explainHPA pairs the metric targets of hpa with their current values and explains the scaling decision
+// from its conditions, bounds and metrics.
+func explainHPA(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler, kubernetesClient kubernetes.Interface) *hpaExplanation {
+	status := hpa.Status
+	explanation := &hpaExplanation{
+		Name:            hpa.Name,
+		Target:          hpa.Spec.ScaleTargetRef.Kind + "/" + hpa.Spec.ScaleTargetRef.Name,
+		MinReplicas:     replicasOrDefault(hpa.Spec.MinReplicas),
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: status.CurrentReplicas,
+		DesiredReplicas: status.DesiredReplicas,
+		LastScaleTime:   status.LastScaleTime,
+		Metrics:         []hpaMetric{},
+		Conditions:      status.Conditions,
+		Explanation:     []string{},
+	}
+	explain := func(format string, args ...any) {
+		explanation.Explanation = append(explanation.Explanation, fmt.Sprintf(format, args...))
+	}
+
+	conditions := map[autoscalingv2.HorizontalPodAutoscalerConditionType]autoscalingv2.HorizontalPodAutoscalerCondition{}
+	for _, condition := range status.Conditions {
+		conditions[condition.Type] = condition
+	}
+	if condition, ok := conditions[autoscalingv2.AbleToScale]; ok && condition.Status == corev1.ConditionFalse {
+		explain("The autoscaler cannot scale %s (%s): %s", explanation.Target, condition.Reason, condition.Message)
+	}
+	if condition, ok := conditions[autoscalingv2.ScalingActive]; ok && condition.Status == corev1.ConditionFalse {
+		explain("Scaling is not active (%s): %s", condition.Reason, condition.Message)
+	}
+	if len(status.Conditions) == 0 {
+		explain("The autoscaler has not reported any conditions yet; the controller may not have processed it")
+	}
+
+	usesResourceMetrics := false
+	withinTolerance, aboveTarget, belowTarget := 0, 0, 0
+	for _, metric := range hpa.Spec.Metrics {
+		if metric.Type == autoscalingv2.ResourceMetricSourceType || metric.Type == autoscalingv2.ContainerResourceMetricSourceType {
+			usesResourceMetrics = true
+		}
+		described := describeHPAMetric(metric, status.CurrentMetrics)
+		explanation.Metrics = append(explanation.Metrics, described)
+		switch {
+		case !described.known && described.Current == "<unknown>":
+			explain("Metric %s has no current value, so it cannot drive scaling", described.Name)
+		case !described.known:
+			explain("Metric %s has no usable target, so it cannot drive scaling", described.Name)
+		case math.Abs(described.Ratio-1) <= hpaTolerance:
+			withinTolerance++
+			explain("Metric %s is at %s against a target of %s, within the %.0f%% tolerance, so it does not cause scaling", described.Name, described.Current, described.Target, hpaTolerance*100)
+		case described.Ratio > 1:
+			aboveTarget++
+			explain("Metric %s is at %s against a target of %s, which asks for about %.0f%% more replicas", described.Name, described.Current, described.Target, (described.Ratio-1)*100)
+		default:
+			belowTarget++
+			explain("Metric %s is at %s against a target of %s, which allows about %.0f%% fewer replicas", described.Name, described.Current, described.Target, (1-described.Ratio)*100)
+		}
+	}
+	if usesResourceMetrics {
+		for _, message := range missingResourceRequests(ctx, hpa, kubernetesClient) {
+			explain("%s; resource utilization cannot be computed without requests", message)
+		}
+	}
+
+	if condition, ok := conditions[autoscalingv2.ScalingLimited]; ok && condition.Status == corev1.ConditionTrue {
+		explain("The desired replica count is limited (%s): %s", condition.Reason, condition.Message)
+	}
+	if condition, ok := conditions[autoscalingv2.AbleToScale]; ok && condition.Status == corev1.ConditionTrue &&
+		(condition.Reason == "ScaleDownStabilized" || condition.Reason == "ScaleUpStabilized") {
+		explain("A stabilization window is holding back scaling: %s", condition.Message)
+	}
+
+	switch {
+	case status.DesiredReplicas > status.CurrentReplicas:
+		explain("The autoscaler is scaling %s up from %d to %d replicas", explanation.Target, status.CurrentReplicas, status.DesiredReplicas)
+	case status.DesiredReplicas < status.CurrentReplicas:
+		explain("The autoscaler is scaling %s down from %d to %d replicas", explanation.Target, status.CurrentReplicas, status.DesiredReplicas)
+	case aboveTarget > 0 && status.CurrentReplicas >= explanation.MaxReplicas:
+		explain("%s is at maxReplicas (%d) while metrics are above target; raise maxReplicas to allow more replicas", explanation.Target, explanation.MaxReplicas)
+	case belowTarget > 0 && aboveTarget == 0 && withinTolerance == 0 && status.CurrentReplicas <= explanation.MinReplicas:
+		explain("%s is at minReplicas (%d) while metrics are below target, so it cannot scale down further", explanation.Target, explanation.MinReplicas)
+	default:
+		explain("%s is at the desired replica count (%d)", explanation.Target, status.CurrentReplicas)
+	}
+	if status.LastScaleTime != nil {
+		explain("The last scale happened at %s", formatTime(status.LastScaleTime.Time))
+	}
+	return explanation
+}
+
+// describeHPAMetric pairs a metric target with its current value from the autoscaler status.
+func describeHPAMetric(metric autoscalingv2.MetricSpec, current []autoscalingv2.MetricStatus) hpaMetric {
+	described := hpaMetric{Type: string(metric.Type), Current: "<unknown>"}
+	var target autoscalingv2.MetricTarget
+	var value *autoscalingv2.MetricValueStatus
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if metric.Resource == nil {
+			return described
+		}
+		described.Name = string(metric.Resource.Name)
+		target = metric.Resource.Target
+		for _, status := range current {
+			if status.Resource != nil && status.Resource.Name == metric.Resource.Name {
+				value = &status.Resource.Current
+			}
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if metric.ContainerResource == nil {
+			return described
+		}
+		described.Name = metric.ContainerResource.Container + "/" + string(metric.ContainerResource.Name)
+		target = metric.ContainerResource.Target
+		for _, status := range current {
+			if status.ContainerResource != nil && status.ContainerResource.Name == metric.ContainerResource.Name && status.ContainerResource.Container == metric.ContainerResource.Container {
+				value = &status.ContainerResource.Current
+			}
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if metric.Pods == nil {
+			return described
+		}
+		described.Name = metric.Pods.Metric.Name
+		target = metric.Pods.Target
+		for _, status := range current {
+			if status.Pods != nil && status.Pods.Metric.Name == metric.Pods.Metric.Name {
+				value = &status.Pods.Current
+			}
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if metric.Object == nil {
+			return described
+		}
+		described.Name = metric.Object.DescribedObject.Kind + "/" + metric.Object.DescribedObject.Name + " " + metric.Object.Metric.Name
+		target = metric.Object.Target
+		for _, status := range current {
+			if status.Object != nil && status.Object.Metric.Name == metric.Object.Metric.Name && status.Object.DescribedObject == metric.Object.DescribedObject {
+				value = &status.Object.Current
+			}
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if metric.External == nil {
+			return described
+		}
+		described.Name = metric.External.Metric.Name
+		target = metric.External.Target
+		for _, status := range current {
+			if status.External != nil && status.External.Metric.Name == metric.External.Metric.Name {
+				value = &status.External.Current
+			}
+		}
+	}
+
+	switch target.Type {
+	case autoscalingv2.UtilizationMetricType:
+		if target.AverageUtilization != nil {
+			described.Target = fmt.Sprintf("%d%%", *target.AverageUtilization)
+		}
+		if value != nil && value.AverageUtilization != nil {
+			described.Current = fmt.Sprintf("%d%%", *value.AverageUtilization)
+			if target.AverageUtilization != nil && *target.AverageUtilization > 0 {
+				described.Ratio = float64(*value.AverageUtilization) / float64(*target.AverageUtilization)
+				described.known = true
+			}
+		}
+	case autoscalingv2.AverageValueMetricType:
+		described.Target, described.Current, described.Ratio, described.known = compareQuantities(target.AverageValue, valueOf(value, true))
+	case autoscalingv2.ValueMetricType:
+		described.Target, described.Current, described.Ratio, described.known = compareQuantities(target.Value, valueOf(value, false))
+	}
+	return described
+}
+
+func valueOf(value *autoscalingv2.MetricValueStatus, average bool) *resource.Quantity {
+	if value == nil {
+		return nil
+	}
+	if average {
+		return value.AverageValue
+	}
+	return value.Value
+}
+
+// compareQuantities formats target and current and returns their ratio, reporting whether it could be computed.
+func compareQuantities(target *resource.Quantity, current *resource.Quantity) (string, string, float64, bool) {
+	targetString, currentString, ratio, known := "<unset>", "<unknown>", 0.0, false
+	if target != nil {
+		targetString = target.String()
+	}
+	if current != nil {
+		currentString = current.String()
+		if target != nil && !target.IsZero() {
+			ratio = current.AsApproximateFloat64() / target.AsApproximateFloat64()
+			known = true
+		}
+	}
+	return targetString, currentString, ratio, known
+}
+
+// missingResourceRequests lists the containers of the scale target's pod template that lack a request for a
+// resource metric of hpa. Only Deployments, StatefulSets and ReplicaSets are checked.
+func missingResourceRequests(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler, kubernetesClient kubernetes.Interface) []string {
+	targetRef := hpa.Spec.ScaleTargetRef
+	var podSpec *corev1.PodSpec
+	switch targetRef.Kind {
+	case "Deployment":
+		if target, err := kubernetesClient.AppsV1().Deployments(hpa.Namespace).Get(ctx, targetRef.Name, metav1.GetOptions{}); err == nil {
+			podSpec = &target.Spec.Template.Spec
+		}
+	case "StatefulSet":
+		if target, err := kubernetesClient.AppsV1().StatefulSets(hpa.Namespace).Get(ctx, targetRef.Name, metav1.GetOptions{}); err == nil {
+			podSpec = &target.Spec.Template.Spec
+		}
+	case "ReplicaSet":
+		if target, err := kubernetesClient.AppsV1().ReplicaSets(hpa.Namespace).Get(ctx, targetRef.Name, metav1.GetOptions{}); err == nil {
+			podSpec = &target.Spec.Template.Spec
+		}
+	}
+	if podSpec == nil {
+		return nil
+	}
+
+	var missing []string
+	for _, metric := range hpa.Spec.Metrics {
+		var resourceName corev1.ResourceName
+		container := ""
+		switch {
+		case metric.Type == autoscalingv2.ResourceMetricSourceType && metric.Resource != nil && metric.Resource.Target.Type == autoscalingv2.UtilizationMetricType:
+			resourceName = metric.Resource.Name
+		case metric.Type == autoscalingv2.ContainerResourceMetricSourceType && metric.ContainerResource != nil && metric.ContainerResource.Target.Type == autoscalingv2.UtilizationMetricType:
+			resourceName = metric.ContainerResource.Name
+			container = metric.ContainerResource.Container
+		default:
+			continue
+		}
+		for _, podContainer := range podSpec.Containers {
+			if container != "" && podContainer.Name != container {
+				continue
+			}
+			if _, ok := podContainer.Resources.Requests[resourceName]; !ok {
+				missing = append(missing, fmt.Sprintf("Container %s of %s/%s has no %s request", podContainer.Name, targetRef.Kind, targetRef.Name, resourceName))
+			}
+		}
+	}
+	return missing
+}
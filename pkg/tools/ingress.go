@@ -0,0 +1,291 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+)
+
+// ingressRoutes is the routing table of an ingress, flattened to one row per host and path.
+type ingressRoutes struct {
+	Ingress      string         `json:"ingress"`
+	IngressClass string         `json:"ingressClass,omitempty"`
+	Routes       []ingressRoute `json:"routes"`
+	TLS          []ingressTLS   `json:"tls,omitempty"`
+}
+
+type ingressRoute struct {
+	Host     string `json:"host"`
+	Path     string `json:"path"`
+	PathType string `json:"pathType,omitempty"`
+	// Backend is service:port, or Kind/name for resource backends.
+	Backend        string   `json:"backend"`
+	Endpoints      int      `json:"endpoints"`
+	ReadyEndpoints int      `json:"readyEndpoints"`
+	Problems       []string `json:"problems,omitempty"`
+}
+
+type ingressTLS struct {
+	Hosts    []string `json:"hosts"`
+	Secret   string   `json:"secret,omitempty"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+func ingressMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, ingressInterface v1.IngressInterface, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		ingressJson := []byte(resourceSpec)
+		var ingressSpec networkingv1.Ingress
+		if err := json.Unmarshal(ingressJson, &ingressSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		ingress, err := ingressInterface.Create(
+			ctx,
+			&networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      ingressSpec.Labels,
+					Annotations: ingressSpec.Annotations,
+				},
+				Spec: ingressSpec.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdIngressSpec, err := json.Marshal(ingress)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created ingress: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdIngressSpec), nil
+	case "delete":
+		err := ingressInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted ingress " + name + " in namespace " + namespace), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var ingressSpec networkingv1.Ingress
+		if err := json.Unmarshal([]byte(resourceSpec), &ingressSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		ingress, err := ingressInterface.Update(
+			ctx,
+			&networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      ingressSpec.Labels,
+					Annotations: ingressSpec.Annotations,
+				},
+				Spec: ingressSpec.Spec,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedIngressSpec, err := json.Marshal(ingress)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated ingress: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedIngressSpec), nil
+	case "get":
+		ingress, err := ingressInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		ingressSpec, err := json.Marshal(ingress)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal ingress: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(ingressSpec), nil
+	case "list":
+		ingresses, err := ingressInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var ingressNames []string
+		for _, ingress := range ingresses.Items {
+			ingressNames = append(ingressNames, ingress.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(ingressNames), nil
+	case "routes":
+		ingress, err := ingressInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		routes, err := resolveIngressRoutes(ctx, ingress, kubernetesClient)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(routes), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// resolveIngressRoutes flattens the rules of ingress and resolves each backend service to its endpoints,
+// flagging backends that point to missing services or ports and TLS hosts without a usable secret.
+func resolveIngressRoutes(ctx context.Context, ingress *networkingv1.Ingress, kubernetesClient kubernetes.Interface) (*ingressRoutes, error) {
+	result := &ingressRoutes{
+		Ingress: ingress.Name,
+		Routes:  []ingressRoute{},
+	}
+	if ingress.Spec.IngressClassName != nil {
+		result.IngressClass = *ingress.Spec.IngressClassName
+	}
+
+	services := map[string]*corev1.Service{}
+	resolve := func(host string, path string, pathType *networkingv1.PathType, backend networkingv1.IngressBackend) error {
+		route := ingressRoute{Host: host, Path: path}
+		if pathType != nil {
+			route.PathType = string(*pathType)
+		}
+		if backend.Resource != nil {
+			route.Backend = backend.Resource.Kind + "/" + backend.Resource.Name
+			result.Routes = append(result.Routes, route)
+			return nil
+		}
+		if backend.Service == nil {
+			route.Problems = append(route.Problems, "no backend")
+			result.Routes = append(result.Routes, route)
+			return nil
+		}
+
+		port := backend.Service.Port.Name
+		if port == "" {
+			port = fmt.Sprint(backend.Service.Port.Number)
+		}
+		route.Backend = backend.Service.Name + ":" + port
+
+		service, seen := services[backend.Service.Name]
+		if !seen {
+			var err error
+			service, err = kubernetesClient.CoreV1().Services(ingress.Namespace).Get(ctx, backend.Service.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				service = nil
+			} else if err != nil {
+				return err
+			}
+			services[backend.Service.Name] = service
+		}
+		if service == nil {
+			route.Problems = append(route.Problems, "service "+backend.Service.Name+" not found")
+			result.Routes = append(result.Routes, route)
+			return nil
+		}
+
+		portFound := service.Spec.Type == corev1.ServiceTypeExternalName
+		for _, servicePort := range service.Spec.Ports {
+			if (backend.Service.Port.Name != "" && servicePort.Name == backend.Service.Port.Name) ||
+				(backend.Service.Port.Number != 0 && servicePort.Port == backend.Service.Port.Number) {
+				portFound = true
+			}
+		}
+		if !portFound {
+			route.Problems = append(route.Problems, "service "+service.Name+" has no port "+port)
+		}
+
+		endpoints, err := resolveServiceEndpoints(ctx, service, kubernetesClient.DiscoveryV1().EndpointSlices(ingress.Namespace), kubernetesClient.CoreV1().Pods(ingress.Namespace))
+		if err != nil {
+			return err
+		}
+		route.Endpoints = len(endpoints.Endpoints)
+		for _, endpoint := range endpoints.Endpoints {
+			if endpoint.Ready {
+				route.ReadyEndpoints++
+			}
+		}
+		route.Problems = append(route.Problems, endpoints.Warnings...)
+		result.Routes = append(result.Routes, route)
+		return nil
+	}
+
+	if ingress.Spec.DefaultBackend != nil {
+		if err := resolve("*", "(default)", nil, *ingress.Spec.DefaultBackend); err != nil {
+			return nil, err
+		}
+	}
+	for _, rule := range ingress.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if err := resolve(host, valueOrNone(path.Path), path.PathType, path.Backend); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, tls := range ingress.Spec.TLS {
+		entry := ingressTLS{Hosts: tls.Hosts, Secret: tls.SecretName}
+		if tls.SecretName == "" {
+			entry.Problems = append(entry.Problems, "no secretName, the ingress controller's default certificate is used")
+			result.TLS = append(result.TLS, entry)
+			continue
+		}
+		secret, err := kubernetesClient.CoreV1().Secrets(ingress.Namespace).Get(ctx, tls.SecretName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			entry.Problems = append(entry.Problems, "secret "+tls.SecretName+" not found")
+			result.TLS = append(result.TLS, entry)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		certificates, err := parseCertificates(secret.Data[corev1.TLSCertKey])
+		if err != nil || len(certificates) == 0 {
+			entry.Problems = append(entry.Problems, "secret "+tls.SecretName+" holds no valid certificate in "+corev1.TLSCertKey)
+			result.TLS = append(result.TLS, entry)
+			continue
+		}
+		for _, host := range tls.Hosts {
+			if err := certificates[0].VerifyHostname(host); err != nil {
+				entry.Problems = append(entry.Problems, "certificate in secret "+tls.SecretName+" does not cover "+host)
+			}
+		}
+		result.TLS = append(result.TLS, entry)
+	}
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+)
+
+func ingressclassMCPResponse(ctx context.Context, name string, action string, resourceSpec string, ingressClassInterface v1.IngressClassInterface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		ingressClassJson := []byte(resourceSpec)
+		var ingressClassSpec networkingv1.IngressClass
+		if err := json.Unmarshal(ingressClassJson, &ingressClassSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		ingressClass, err := ingressClassInterface.Create(
+			ctx,
+			&networkingv1.IngressClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Labels:      ingressClassSpec.Labels,
+					Annotations: ingressClassSpec.Annotations,
+				},
+				Spec: ingressClassSpec.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdIngressClassSpec, err := json.Marshal(ingressClass)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created ingressclass: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdIngressClassSpec), nil
+	case "delete":
+		err := ingressClassInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted ingressclass " + name), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var ingressClassSpec networkingv1.IngressClass
+		if err := json.Unmarshal([]byte(resourceSpec), &ingressClassSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		ingressClass, err := ingressClassInterface.Update(
+			ctx,
+			&networkingv1.IngressClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Labels:      ingressClassSpec.Labels,
+					Annotations: ingressClassSpec.Annotations,
+				},
+				Spec: ingressClassSpec.Spec,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedIngressClassSpec, err := json.Marshal(ingressClass)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated ingressclass: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedIngressClassSpec), nil
+	case "get":
+		ingressClass, err := ingressClassInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		ingressClassSpec, err := json.Marshal(ingressClass)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal ingressclass: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(ingressClassSpec), nil
+	case "list":
+		ingressClasses, err := ingressClassInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var ingressClassNames []string
+		for _, ingressClass := range ingressClasses.Items {
+			ingressClassNames = append(ingressClassNames, ingressClass.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(ingressClassNames), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
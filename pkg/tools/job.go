@@ -3,14 +3,52 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
 	v1 "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
-func jobMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, jobInterface v1.JobInterface) (*mcp.CallToolResult, error) {
+const jobLogTailLines = 20
+
+// jobGeneratedLabels are added to jobs and their pod templates by the job controller.
+var jobGeneratedLabels = []string{
+	"controller-uid",
+	"job-name",
+	batchv1.ControllerUidLabel,
+	batchv1.JobNameLabel,
+}
+
+// jobResult is the final state of a job returned by the wait action.
+type jobResult struct {
+	Name       string                 `json:"name"`
+	Status     string                 `json:"status"`
+	Conditions []batchv1.JobCondition `json:"conditions"`
+	Pods       []jobPod               `json:"pods"`
+}
+
+type jobPod struct {
+	Name       string            `json:"name"`
+	Phase      corev1.PodPhase   `json:"phase"`
+	Containers []jobPodContainer `json:"containers"`
+}
+
+type jobPodContainer struct {
+	Name     string `json:"name"`
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Logs     string `json:"logs,omitempty"`
+}
+
+func jobMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, images []string, timeout int, jobInterface v1.JobInterface, podInterface corev1client.PodInterface) (*mcp.CallToolResult, error) {
 
 	switch action {
 	case "create":
@@ -40,6 +78,7 @@ func jobMCPResponse(ctx context.Context, name string, namespace string, action s
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		redactPodSpec(&job.ObjectMeta, &job.Spec.Template.Spec)
 		createdJobSpec, err := json.Marshal(job)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal created job: " + err.Error()), nil
@@ -70,6 +109,7 @@ func jobMCPResponse(ctx context.Context, name string, namespace string, action s
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&job.ObjectMeta, &job.Spec.Template.Spec)
 		updatedJobSpec, err := json.Marshal(job)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal updated job: " + err.Error()), nil
@@ -84,6 +124,7 @@ func jobMCPResponse(ctx context.Context, name string, namespace string, action s
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&job.ObjectMeta, &job.Spec.Template.Spec)
 		jobSpec, err := json.Marshal(job)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal job: " + err.Error()), nil
@@ -102,6 +143,222 @@ func jobMCPResponse(ctx context.Context, name string, namespace string, action s
 			jobNames = append(jobNames, job.Name)
 		}
 		return mcp.NewToolResultStructuredOnly(jobNames), nil
+	case "set_image":
+		job, err := jobInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, changes, err := setImagePatch(&job.Spec.Template.Spec, images, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		_, err = jobInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(changes), nil
+	case "edit_template":
+		job, err := jobInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, container, err := editTemplatePatch(&job.Spec.Template.Spec, resourceSpec, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		job, err = jobInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactPodSpec(&job.ObjectMeta, &job.Spec.Template.Spec)
+		return mcp.NewToolResultStructuredOnly(findContainer(&job.Spec.Template.Spec, container)), nil
+	case "suspend", "resume":
+		suspend := action == "suspend"
+		patch, err := json.Marshal(map[string]any{"spec": map[string]any{"suspend": suspend}})
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal patch: " + err.Error()), nil
+		}
+		_, err = jobInterface.Patch(
+			ctx,
+			name,
+			types.MergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if suspend {
+			return mcp.NewToolResultText("Suspended job " + name + " in namespace " + namespace), nil
+		}
+		return mcp.NewToolResultText("Resumed job " + name + " in namespace " + namespace), nil
+	case "retry":
+		job, err := jobInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if jobFinishedStatus(job) != batchv1.JobFailed {
+			return mcp.NewToolResultError("Job " + name + " has not failed"), nil
+		}
+
+		// The selector and these labels are generated from the old job's UID and must not be reused.
+		labels := withoutLabels(job.Labels, jobGeneratedLabels)
+		template := *job.Spec.Template.DeepCopy()
+		template.Labels = withoutLabels(template.Labels, jobGeneratedLabels)
+		spec := *job.Spec.DeepCopy()
+		spec.Selector = nil
+		spec.ManualSelector = nil
+		spec.Template = template
+
+		jobName := name
+		if len(jobName) > 51 {
+			jobName = jobName[:51]
+		}
+		retriedJob, err := jobInterface.Create(
+			ctx,
+			&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        jobName + "-retry-" + utilrand.String(5),
+					Namespace:   namespace,
+					Labels:      labels,
+					Annotations: job.Annotations,
+				},
+				Spec: spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactPodSpec(&retriedJob.ObjectMeta, &retriedJob.Spec.Template.Spec)
+		createdJobSpec, err := json.Marshal(retriedJob)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created job: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(createdJobSpec), nil
+	case "wait":
+		if timeout <= 0 {
+			timeout = 300
+		}
+
+		var job *batchv1.Job
+		err := wait.PollUntilContextTimeout(ctx, 2*time.Second, time.Duration(timeout)*time.Second, true, func(ctx context.Context) (bool, error) {
+			var err error
+			job, err = jobInterface.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			return jobFinishedStatus(job) != "", nil
+		})
+		if err != nil {
+			if wait.Interrupted(err) {
+				return mcp.NewToolResultError(fmt.Sprintf("Timed out after %ds waiting for job %s to finish", timeout, name)), nil
+			}
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pods, err := podInterface.List(
+			ctx,
+			metav1.ListOptions{LabelSelector: selector.String()},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		result := jobResult{
+			Name:       job.Name,
+			Status:     string(jobFinishedStatus(job)),
+			Conditions: job.Status.Conditions,
+		}
+		for _, pod := range pods.Items {
+			result.Pods = append(result.Pods, jobPodStatus(ctx, &pod, podInterface))
+		}
+		return mcp.NewToolResultStructuredOnly(result), nil
 	}
 	return mcp.NewToolResultError("Unknown action: " + action), nil
 }
+
+// jobFinishedStatus returns JobComplete or JobFailed once the job has finished, and "" before that.
+func jobFinishedStatus(job *batchv1.Job) batchv1.JobConditionType {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return condition.Type
+		}
+	}
+	return ""
+}
+
+// jobPodStatus collects the exit codes of a job pod's containers, with the tail of the logs of failed containers.
+func jobPodStatus(ctx context.Context, pod *corev1.Pod, podInterface corev1client.PodInterface) jobPod {
+	result := jobPod{Name: pod.Name, Phase: pod.Status.Phase}
+	for _, status := range pod.Status.ContainerStatuses {
+		container := jobPodContainer{Name: status.Name}
+		terminated := status.State.Terminated
+		// A container restarted after failing keeps the failed run in its last termination state, with its logs
+		// in the previous instance.
+		previous := false
+		if terminated == nil {
+			terminated = status.LastTerminationState.Terminated
+			previous = terminated != nil
+		}
+		if terminated != nil {
+			container.ExitCode = &terminated.ExitCode
+			container.Reason = terminated.Reason
+			if terminated.ExitCode != 0 {
+				tailLines := int64(jobLogTailLines)
+				logs, err := podInterface.GetLogs(pod.Name, &corev1.PodLogOptions{
+					Container: status.Name,
+					TailLines: &tailLines,
+					Previous:  previous,
+				}).DoRaw(ctx)
+				if err != nil {
+					container.Logs = "Failed to get logs: " + err.Error()
+				} else {
+					container.Logs = string(logs)
+				}
+			}
+		}
+		result.Containers = append(result.Containers, container)
+	}
+	return result
+}
+
+// withoutLabels returns a copy of labels without the given keys.
+func withoutLabels(labels map[string]string, keys []string) map[string]string {
+	result := make(map[string]string, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	for _, key := range keys {
+		delete(result, key)
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+func limitrangeMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, limitRangeInterface v1.LimitRangeInterface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		limitRangeJson := []byte(resourceSpec)
+		var limitRangeSpec corev1.LimitRange
+		if err := json.Unmarshal(limitRangeJson, &limitRangeSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		limitRange, err := limitRangeInterface.Create(
+			ctx,
+			&corev1.LimitRange{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      limitRangeSpec.Labels,
+					Annotations: limitRangeSpec.Annotations,
+				},
+				Spec: limitRangeSpec.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdLimitRangeSpec, err := json.Marshal(limitRange)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created limitrange: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdLimitRangeSpec), nil
+	case "delete":
+		err := limitRangeInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted limitrange " + name + " in namespace " + namespace), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var limitRangeSpec corev1.LimitRange
+		if err := json.Unmarshal([]byte(resourceSpec), &limitRangeSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		limitRange, err := limitRangeInterface.Update(
+			ctx,
+			&corev1.LimitRange{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      limitRangeSpec.Labels,
+					Annotations: limitRangeSpec.Annotations,
+				},
+				Spec: limitRangeSpec.Spec,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedLimitRangeSpec, err := json.Marshal(limitRange)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated limitrange: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedLimitRangeSpec), nil
+	case "get":
+		limitRange, err := limitRangeInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		limitRangeSpec, err := json.Marshal(limitRange)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal limitrange: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(limitRangeSpec), nil
+	case "list":
+		limitRanges, err := limitRangeInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var limitRangeNames []string
+		for _, limitRange := range limitRanges.Items {
+			limitRangeNames = append(limitRangeNames, limitRange.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(limitRangeNames), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
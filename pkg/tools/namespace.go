@@ -0,0 +1,246 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// podSecurityLevels are the Pod Security Admission levels that can be set on a namespace.
+var podSecurityLevels = []string{"privileged", "baseline", "restricted"}
+
+// namespaceStatus explains the state of a namespace, including what keeps it in Terminating.
+type namespaceStatus struct {
+	Name               string                      `json:"name"`
+	Phase              corev1.NamespacePhase       `json:"phase"`
+	DeletionTimestamp  *metav1.Time                `json:"deletionTimestamp,omitempty"`
+	Finalizers         []corev1.FinalizerName      `json:"finalizers,omitempty"`
+	Conditions         []corev1.NamespaceCondition `json:"conditions,omitempty"`
+	RemainingResources []remainingResource         `json:"remainingResources,omitempty"`
+	// UnlistedResources are the resource types, or group versions, that could not be checked for remaining objects.
+	UnlistedResources []string `json:"unlistedResources,omitempty"`
+	Explanation       []string `json:"explanation,omitempty"`
+}
+
+type remainingResource struct {
+	APIVersion        string       `json:"apiVersion"`
+	Resource          string       `json:"resource"`
+	Name              string       `json:"name"`
+	Finalizers        []string     `json:"finalizers,omitempty"`
+	DeletionTimestamp *metav1.Time `json:"deletionTimestamp,omitempty"`
+}
+
+func namespaceMCPResponse(ctx context.Context, name string, action string, resourceSpec string, podSecurity string, namespaceInterface v1.NamespaceInterface, discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+		var namespaceSpec corev1.Namespace
+		if resourceSpec != "" {
+			if err := json.Unmarshal([]byte(resourceSpec), &namespaceSpec); err != nil {
+				return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+			}
+		}
+
+		labels := namespaceSpec.Labels
+		if podSecurity != "" {
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			for _, mode := range []string{"enforce", "audit", "warn"} {
+				labels["pod-security.kubernetes.io/"+mode] = podSecurity
+			}
+		}
+
+		namespace, err := namespaceInterface.Create(
+			ctx,
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Labels:      labels,
+					Annotations: namespaceSpec.Annotations,
+				},
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdNamespaceSpec, err := json.Marshal(namespace)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created namespace: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdNamespaceSpec), nil
+	case "delete":
+		err := namespaceInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted namespace " + name), nil
+	case "get":
+		namespace, err := namespaceInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		namespaceSpec, err := json.Marshal(namespace)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal namespace: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(namespaceSpec), nil
+	case "list":
+		namespaces, err := namespaceInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var namespaceNames []string
+		for _, namespace := range namespaces.Items {
+			namespaceNames = append(namespaceNames, namespace.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(namespaceNames), nil
+	case "status":
+		namespace, err := namespaceInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		status := namespaceStatus{
+			Name:              namespace.Name,
+			Phase:             namespace.Status.Phase,
+			DeletionTimestamp: namespace.DeletionTimestamp,
+			Finalizers:        namespace.Spec.Finalizers,
+			Conditions:        namespace.Status.Conditions,
+		}
+		if namespace.Status.Phase != corev1.NamespaceTerminating {
+			return mcp.NewToolResultStructuredOnly(status), nil
+		}
+
+		status.RemainingResources, status.UnlistedResources, err = namespaceRemainingResources(ctx, name, discoveryClient, dynamicClient)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		status.Explanation = explainTerminatingNamespace(&status)
+		return mcp.NewToolResultStructuredOnly(status), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// namespaceRemainingResources lists every object still present in namespace, across all listable namespaced resource types.
+// It also returns the group versions that could not be discovered and the resource types that could not be listed,
+// e.g. because access is forbidden or an aggregated API is unavailable, with the reason.
+func namespaceRemainingResources(ctx context.Context, namespace string, discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface) ([]remainingResource, []string, error) {
+	var unlisted []string
+	resourceLists, err := discoveryClient.ServerPreferredNamespacedResources()
+	// Partial discovery failures are common with broken aggregated APIs; use whatever was discovered.
+	var groupDiscoveryFailed *discovery.ErrGroupDiscoveryFailed
+	if errors.As(err, &groupDiscoveryFailed) {
+		for groupVersion, groupErr := range groupDiscoveryFailed.Groups {
+			unlisted = append(unlisted, fmt.Sprintf("%s: %v", groupVersion, groupErr))
+		}
+	} else if err != nil {
+		return nil, nil, err
+	}
+	resourceLists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list", "delete"}}, resourceLists)
+
+	remaining := []remainingResource{}
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range resourceList.APIResources {
+			// Events are cleaned up by the API server and never block deletion.
+			if resource.Name == "events" {
+				continue
+			}
+			objects, err := dynamicClient.Resource(groupVersion.WithResource(resource.Name)).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				unlisted = append(unlisted, fmt.Sprintf("%s: %v", groupVersion.WithResource(resource.Name).GroupResource(), err))
+				continue
+			}
+			for _, object := range objects.Items {
+				remaining = append(remaining, remainingResource{
+					APIVersion:        resourceList.GroupVersion,
+					Resource:          resource.Name,
+					Name:              object.GetName(),
+					Finalizers:        object.GetFinalizers(),
+					DeletionTimestamp: object.GetDeletionTimestamp(),
+				})
+			}
+		}
+	}
+	sort.Slice(remaining, func(i, j int) bool {
+		if remaining[i].Resource != remaining[j].Resource {
+			return remaining[i].Resource < remaining[j].Resource
+		}
+		return remaining[i].Name < remaining[j].Name
+	})
+	sort.Strings(unlisted)
+	return remaining, unlisted, nil
+}
+
+// explainTerminatingNamespace summarizes why a terminating namespace has not been removed yet.
+func explainTerminatingNamespace(status *namespaceStatus) []string {
+	var explanation []string
+	for _, condition := range status.Conditions {
+		if condition.Status == corev1.ConditionTrue {
+			explanation = append(explanation, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+		}
+	}
+
+	finalizers := map[string]int{}
+	for _, resource := range status.RemainingResources {
+		for _, finalizer := range resource.Finalizers {
+			finalizers[finalizer]++
+		}
+	}
+	var finalizerNames []string
+	for finalizer := range finalizers {
+		finalizerNames = append(finalizerNames, finalizer)
+	}
+	sort.Strings(finalizerNames)
+	for _, finalizer := range finalizerNames {
+		explanation = append(explanation, fmt.Sprintf("%d remaining resources are held by finalizer %s; the controller owning it must finish or the finalizer must be removed", finalizers[finalizer], finalizer))
+	}
+
+	if len(status.RemainingResources) > 0 && len(finalizers) == 0 {
+		explanation = append(explanation, fmt.Sprintf("%d resources are still being deleted", len(status.RemainingResources)))
+	}
+	if len(status.UnlistedResources) > 0 {
+		explanation = append(explanation, fmt.Sprintf("%d resource types could not be listed and may hold blocking resources: %s", len(status.UnlistedResources), strings.Join(status.UnlistedResources, "; ")))
+	}
+	if len(status.RemainingResources) == 0 && len(status.UnlistedResources) == 0 && len(status.Finalizers) > 0 {
+		var names []string
+		for _, finalizer := range status.Finalizers {
+			names = append(names, string(finalizer))
+		}
+		explanation = append(explanation, "No resources remain; the namespace is waiting for its own finalizers: "+strings.Join(names, ", "))
+	}
+	return explanation
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+)
+
+func networkpolicyMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, query trafficQuery, networkPolicyInterface v1.NetworkPolicyInterface, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		networkPolicyJson := []byte(resourceSpec)
+		var networkPolicySpec networkingv1.NetworkPolicy
+		if err := json.Unmarshal(networkPolicyJson, &networkPolicySpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		networkPolicy, err := networkPolicyInterface.Create(
+			ctx,
+			&networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      networkPolicySpec.Labels,
+					Annotations: networkPolicySpec.Annotations,
+				},
+				Spec: networkPolicySpec.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdNetworkPolicySpec, err := json.Marshal(networkPolicy)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created networkpolicy: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdNetworkPolicySpec), nil
+	case "delete":
+		err := networkPolicyInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted networkpolicy " + name + " in namespace " + namespace), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var networkPolicySpec networkingv1.NetworkPolicy
+		if err := json.Unmarshal([]byte(resourceSpec), &networkPolicySpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		networkPolicy, err := networkPolicyInterface.Update(
+			ctx,
+			&networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      networkPolicySpec.Labels,
+					Annotations: networkPolicySpec.Annotations,
+				},
+				Spec: networkPolicySpec.Spec,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedNetworkPolicySpec, err := json.Marshal(networkPolicy)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated networkpolicy: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedNetworkPolicySpec), nil
+	case "get":
+		networkPolicy, err := networkPolicyInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		networkPolicySpec, err := json.Marshal(networkPolicy)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal networkpolicy: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(networkPolicySpec), nil
+	case "list":
+		networkPolicies, err := networkPolicyInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var networkPolicyNames []string
+		for _, networkPolicy := range networkPolicies.Items {
+			networkPolicyNames = append(networkPolicyNames, networkPolicy.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(networkPolicyNames), nil
+	case "analyze":
+		// For analyze, name and namespace identify the destination pod rather than a policy.
+		analysis, err := analyzeTraffic(ctx, kubernetesClient, query, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(analysis), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
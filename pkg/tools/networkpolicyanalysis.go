@@ -0,0 +1,381 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// networkProtocols are the protocols a NetworkPolicy port can name.
+var networkProtocols = []string{string(corev1.ProtocolTCP), string(corev1.ProtocolUDP), string(corev1.ProtocolSCTP)}
+
+// trafficQuery describes the traffic to analyze. The source is either an existing pod or, for pods that
+// do not exist yet, a namespace and a set of labels.
+type trafficQuery struct {
+	SourcePod       string
+	SourceNamespace string
+	SourceLabels    []string
+	Port            string
+	Protocol        string
+}
+
+// trafficAnalysis is the verdict of the NetworkPolicies on a connection. Traffic is only allowed when
+// both the source's egress and the destination's ingress allow it.
+type trafficAnalysis struct {
+	Source      string         `json:"source"`
+	Destination string         `json:"destination"`
+	Port        string         `json:"port"`
+	Protocol    string         `json:"protocol"`
+	Allowed     bool           `json:"allowed"`
+	Egress      trafficVerdict `json:"egress"`
+	Ingress     trafficVerdict `json:"ingress"`
+	Notes       []string       `json:"notes,omitempty"`
+}
+
+type trafficVerdict struct {
+	// Isolated is set when at least one policy selects the pod for this direction, so only traffic allowed by a rule passes.
+	Isolated  bool     `json:"isolated"`
+	Allowed   bool     `json:"allowed"`
+	Policies  []string `json:"policies,omitempty"`
+	AllowedBy []string `json:"allowedBy,omitempty"`
+	Reason    string   `json:"reason"`
+}
+
+// trafficPeer is one end of the analyzed connection.
+type trafficPeer struct {
+	description     string
+	namespace       string
+	labels          map[string]string
+	namespaceLabels map[string]string
+	// ip is empty when the source is only given by labels.
+	ip string
+}
+
+// analyzeTraffic evaluates every NetworkPolicy that applies to traffic from the source in query to the
+// destination pod. It only reasons about policy semantics; whether the network plugin enforces them is not checked.
+func analyzeTraffic(ctx context.Context, kubernetesClient kubernetes.Interface, query trafficQuery, namespace string, name string) (*trafficAnalysis, error) {
+	destinationPod, err := kubernetesClient.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	destination, err := podPeer(ctx, kubernetesClient, destinationPod)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceNamespace := query.SourceNamespace
+	if sourceNamespace == "" {
+		sourceNamespace = namespace
+	}
+	var source *trafficPeer
+	switch {
+	case query.SourcePod != "":
+		sourcePod, err := kubernetesClient.CoreV1().Pods(sourceNamespace).Get(ctx, query.SourcePod, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if source, err = podPeer(ctx, kubernetesClient, sourcePod); err != nil {
+			return nil, err
+		}
+	case query.SourceNamespace != "" || len(query.SourceLabels) > 0:
+		sourceLabels, err := labels.ConvertSelectorToLabelsMap(strings.Join(query.SourceLabels, ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid sourceLabels: %w", err)
+		}
+		sourceNamespaceObject, err := kubernetesClient.CoreV1().Namespaces().Get(ctx, sourceNamespace, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		source = &trafficPeer{
+			description:     fmt.Sprintf("pods in %s with labels %s", sourceNamespace, sourceLabels.String()),
+			namespace:       sourceNamespace,
+			labels:          sourceLabels,
+			namespaceLabels: sourceNamespaceObject.Labels,
+		}
+		// Without labels the source stands for any pod in the namespace, evaluated as a pod without labels.
+		if len(sourceLabels) == 0 {
+			source.description = "any pod in " + sourceNamespace
+		}
+	default:
+		return nil, fmt.Errorf("sourcePod or sourceNamespace and sourceLabels are required for analyze action")
+	}
+
+	if query.Port == "" {
+		return nil, fmt.Errorf("port is required for analyze action")
+	}
+	protocol := corev1.Protocol(query.Protocol)
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	portNumber, portName, err := resolveTargetPort(destinationPod, query.Port, protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &trafficAnalysis{
+		Source:      source.description,
+		Destination: destination.description,
+		Port:        strconv.Itoa(int(portNumber)),
+		Protocol:    string(protocol),
+	}
+	if portName != "" {
+		analysis.Port += " (" + portName + ")"
+	}
+
+	egressPolicies, err := kubernetesClient.NetworkingV1().NetworkPolicies(source.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ingressPolicies, err := kubernetesClient.NetworkingV1().NetworkPolicies(destination.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ports := portMatcher{protocol: protocol, number: portNumber, name: portName}
+	notes := map[string]bool{}
+	if query.SourcePod == "" && len(source.labels) == 0 {
+		notes["no sourceLabels were given, so the source was evaluated as a pod without labels; policies and rules selecting specific pod labels may treat labeled pods differently"] = true
+	}
+	analysis.Egress, err = evaluatePolicies(egressPolicies.Items, networkingv1.PolicyTypeEgress, source, destination, ports, notes)
+	if err != nil {
+		return nil, err
+	}
+	analysis.Ingress, err = evaluatePolicies(ingressPolicies.Items, networkingv1.PolicyTypeIngress, destination, source, ports, notes)
+	if err != nil {
+		return nil, err
+	}
+	analysis.Allowed = analysis.Egress.Allowed && analysis.Ingress.Allowed
+	analysis.Notes = sortedKeys(notes)
+	return analysis, nil
+}
+
+func podPeer(ctx context.Context, kubernetesClient kubernetes.Interface, pod *corev1.Pod) (*trafficPeer, error) {
+	namespace, err := kubernetesClient.CoreV1().Namespaces().Get(ctx, pod.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &trafficPeer{
+		description:     "pod " + pod.Namespace + "/" + pod.Name,
+		namespace:       pod.Namespace,
+		labels:          pod.Labels,
+		namespaceLabels: namespace.Labels,
+		ip:              pod.Status.PodIP,
+	}, nil
+}
+
+// resolveTargetPort resolves port, a number or a named container port, against the destination pod's containers.
+// Named ports in policies can only match when the port is declared by a container.
+func resolveTargetPort(pod *corev1.Pod, port string, protocol corev1.Protocol) (int32, string, error) {
+	number, err := strconv.Atoi(port)
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			containerProtocol := containerPort.Protocol
+			if containerProtocol == "" {
+				containerProtocol = corev1.ProtocolTCP
+			}
+			if containerProtocol != protocol {
+				continue
+			}
+			if (err == nil && containerPort.ContainerPort == int32(number)) || (err != nil && containerPort.Name == port) {
+				return containerPort.ContainerPort, containerPort.Name, nil
+			}
+		}
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("pod %s has no %s container port named %s", pod.Name, protocol, port)
+	}
+	return int32(number), "", nil
+}
+
+// portMatcher matches the destination port against the ports of a policy rule.
+type portMatcher struct {
+	protocol corev1.Protocol
+	number   int32
+	name     string
+}
+
+func (m portMatcher) matches(ports []networkingv1.NetworkPolicyPort) bool {
+	// A rule without ports applies to all ports.
+	if len(ports) == 0 {
+		return true
+	}
+	for _, port := range ports {
+		protocol := corev1.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+		if protocol != m.protocol {
+			continue
+		}
+		switch {
+		case port.Port == nil:
+			return true
+		case port.Port.Type == intstr.String:
+			if m.name != "" && port.Port.StrVal == m.name {
+				return true
+			}
+		case port.EndPort != nil:
+			if m.number >= port.Port.IntVal && m.number <= *port.EndPort {
+				return true
+			}
+		default:
+			if m.number == port.Port.IntVal {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evaluatePolicies decides whether the policies in the namespace of pod allow traffic in direction between pod and peer.
+func evaluatePolicies(policies []networkingv1.NetworkPolicy, direction networkingv1.PolicyType, pod *trafficPeer, peer *trafficPeer, ports portMatcher, notes map[string]bool) (trafficVerdict, error) {
+	verdict := trafficVerdict{}
+	for _, policy := range policies {
+		selected, err := selectorMatches(&policy.Spec.PodSelector, pod.labels)
+		if err != nil {
+			return verdict, fmt.Errorf("networkpolicy %s: %w", policy.Name, err)
+		}
+		if !selected || !hasPolicyType(&policy, direction) {
+			continue
+		}
+		verdict.Isolated = true
+		verdict.Policies = append(verdict.Policies, policy.Name)
+
+		var rules [][]networkingv1.NetworkPolicyPeer
+		var rulePorts [][]networkingv1.NetworkPolicyPort
+		if direction == networkingv1.PolicyTypeIngress {
+			for _, rule := range policy.Spec.Ingress {
+				rules = append(rules, rule.From)
+				rulePorts = append(rulePorts, rule.Ports)
+			}
+		} else {
+			for _, rule := range policy.Spec.Egress {
+				rules = append(rules, rule.To)
+				rulePorts = append(rulePorts, rule.Ports)
+			}
+		}
+		for i, peers := range rules {
+			matched, err := peersMatch(peers, policy.Namespace, peer, notes)
+			if err != nil {
+				return verdict, fmt.Errorf("networkpolicy %s: %w", policy.Name, err)
+			}
+			if matched && ports.matches(rulePorts[i]) {
+				verdict.AllowedBy = append(verdict.AllowedBy, fmt.Sprintf("%s %s rule %d", policy.Name, strings.ToLower(string(direction)), i+1))
+			}
+		}
+	}
+
+	directionName := strings.ToLower(string(direction))
+	peerRelation := "to"
+	if direction == networkingv1.PolicyTypeIngress {
+		peerRelation = "from"
+	}
+	switch {
+	case !verdict.Isolated:
+		verdict.Allowed = true
+		verdict.Reason = fmt.Sprintf("no policy selects %s for %s, so all %s traffic is allowed", pod.description, directionName, directionName)
+	case len(verdict.AllowedBy) > 0:
+		verdict.Allowed = true
+		verdict.Reason = fmt.Sprintf("%s is isolated for %s, and the traffic is allowed by %s", pod.description, directionName, strings.Join(verdict.AllowedBy, ", "))
+	default:
+		verdict.Reason = fmt.Sprintf("%s is isolated for %s by %s, and none of their rules allow traffic %s %s on port %d",
+			pod.description, directionName, strings.Join(verdict.Policies, ", "), peerRelation, peer.description, ports.number)
+	}
+	return verdict, nil
+}
+
+// hasPolicyType applies the defaulting of policyTypes: Ingress always, Egress only when the policy has egress rules.
+func hasPolicyType(policy *networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return policyType == networkingv1.PolicyTypeIngress || len(policy.Spec.Egress) > 0
+	}
+	for _, declared := range policy.Spec.PolicyTypes {
+		if declared == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// peersMatch reports whether peer is one of peers of a rule in a policy in policyNamespace. An empty list matches everything.
+func peersMatch(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, peer *trafficPeer, notes map[string]bool) (bool, error) {
+	if len(peers) == 0 {
+		return true, nil
+	}
+	for _, candidate := range peers {
+		if candidate.IPBlock != nil {
+			if peer.ip == "" {
+				notes["ipBlock peers were not evaluated because the source is given by labels and has no IP"] = true
+				continue
+			}
+			matched, err := ipBlockMatches(candidate.IPBlock, peer.ip)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				notes["traffic matched an ipBlock by pod IP; ipBlocks are meant for cluster-external addresses and some network plugins ignore them for pod traffic"] = true
+				return true, nil
+			}
+			continue
+		}
+
+		namespaceMatched := peer.namespace == policyNamespace
+		if candidate.NamespaceSelector != nil {
+			matched, err := selectorMatches(candidate.NamespaceSelector, peer.namespaceLabels)
+			if err != nil {
+				return false, err
+			}
+			namespaceMatched = matched
+		}
+		podMatched := true
+		if candidate.PodSelector != nil {
+			matched, err := selectorMatches(candidate.PodSelector, peer.labels)
+			if err != nil {
+				return false, err
+			}
+			podMatched = matched
+		}
+		if namespaceMatched && podMatched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func ipBlockMatches(block *networkingv1.IPBlock, ip string) (bool, error) {
+	address := net.ParseIP(ip)
+	_, cidr, err := net.ParseCIDR(block.CIDR)
+	if err != nil {
+		return false, fmt.Errorf("invalid ipBlock cidr %s: %w", block.CIDR, err)
+	}
+	if address == nil || !cidr.Contains(address) {
+		return false, nil
+	}
+	for _, except := range block.Except {
+		_, exceptCIDR, err := net.ParseCIDR(except)
+		if err != nil {
+			return false, fmt.Errorf("invalid ipBlock except %s: %w", except, err)
+		}
+		if exceptCIDR.Contains(address) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func selectorMatches(selector *metav1.LabelSelector, values map[string]string) (bool, error) {
+	parsed, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return parsed.Matches(labels.Set(values)), nil
+}
@@ -0,0 +1,304 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// mirrorPodAnnotation marks static pods that the kubelet mirrors into the API server.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+const defaultDrainTimeout = 300 * time.Second
+
+// nodeSummary is the information list and get return for a node.
+type nodeSummary struct {
+	Name           string                       `json:"name"`
+	Unschedulable  bool                         `json:"unschedulable"`
+	Conditions     map[string]string            `json:"conditions"`
+	Taints         []string                     `json:"taints,omitempty"`
+	Allocatable    map[string]resource.Quantity `json:"allocatable"`
+	KubeletVersion string                       `json:"kubeletVersion"`
+	PodCount       int                          `json:"podCount"`
+}
+
+// drainOptions control how a node is drained.
+type drainOptions struct {
+	DeleteEmptyDirData bool
+	Force              bool
+	DryRun             bool
+	Timeout            time.Duration
+}
+
+// drainResult describes what a drain did, or would do in a dry run.
+type drainResult struct {
+	Node    string     `json:"node"`
+	DryRun  bool       `json:"dryRun"`
+	Evicted []drainPod `json:"evicted"`
+	Skipped []drainPod `json:"skipped,omitempty"`
+	Blocked []drainPod `json:"blocked,omitempty"`
+	Failed  []drainPod `json:"failed,omitempty"`
+}
+
+type drainPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func nodeMCPResponse(ctx context.Context, name string, action string, options drainOptions, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
+
+	nodeInterface := kubernetesClient.CoreV1().Nodes()
+	switch action {
+	case "get":
+		node, err := nodeInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pods, err := kubernetesClient.CoreV1().Pods("").List(
+			ctx,
+			metav1.ListOptions{FieldSelector: "spec.nodeName=" + name},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(summarizeNode(node, activePodCounts(pods.Items)[name])), nil
+	case "list":
+		nodes, err := nodeInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pods, err := kubernetesClient.CoreV1().Pods("").List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		podCounts := activePodCounts(pods.Items)
+		summaries := []nodeSummary{}
+		for _, node := range nodes.Items {
+			summaries = append(summaries, summarizeNode(&node, podCounts[node.Name]))
+		}
+		return mcp.NewToolResultStructuredOnly(summaries), nil
+	case "cordon", "uncordon":
+		if err := setUnschedulable(ctx, kubernetesClient, name, action == "cordon"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Node %s %sed", name, action)), nil
+	case "drain":
+		result, err := drainNode(ctx, kubernetesClient, name, options)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(result), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+func summarizeNode(node *corev1.Node, podCount int) nodeSummary {
+	summary := nodeSummary{
+		Name:           node.Name,
+		Unschedulable:  node.Spec.Unschedulable,
+		Conditions:     map[string]string{},
+		Allocatable:    map[string]resource.Quantity{},
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		PodCount:       podCount,
+	}
+	for _, condition := range node.Status.Conditions {
+		summary.Conditions[string(condition.Type)] = string(condition.Status)
+	}
+	for _, taint := range node.Spec.Taints {
+		summary.Taints = append(summary.Taints, taint.ToString())
+	}
+	for resourceName, quantity := range node.Status.Allocatable {
+		summary.Allocatable[string(resourceName)] = quantity
+	}
+	return summary
+}
+
+// activePodCounts counts the pods per node that have not finished yet.
+func activePodCounts(pods []corev1.Pod) map[string]int {
+	counts := map[string]int{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			counts[pod.Spec.NodeName]++
+		}
+	}
+	return counts
+}
+
+func setUnschedulable(ctx context.Context, kubernetesClient kubernetes.Interface, name string, unschedulable bool) error {
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"unschedulable": unschedulable}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	_, err = kubernetesClient.CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// drainNode cordons the node and evicts its pods through the eviction API, so PodDisruptionBudgets are respected.
+// DaemonSet-managed and mirror pods are skipped, like kubectl drain --ignore-daemonsets.
+func drainNode(ctx context.Context, kubernetesClient kubernetes.Interface, name string, options drainOptions) (*drainResult, error) {
+	if _, err := kubernetesClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	pods, err := kubernetesClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: "spec.nodeName=" + name})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &drainResult{Node: name, DryRun: options.DryRun, Evicted: []drainPod{}}
+	var evict []corev1.Pod
+	for _, pod := range pods.Items {
+		entry := drainPod{Namespace: pod.Namespace, Name: pod.Name}
+		controller := metav1.GetControllerOf(&pod)
+		finished := pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+		switch {
+		case pod.Annotations[mirrorPodAnnotation] != "":
+			entry.Reason = "mirror pod"
+			result.Skipped = append(result.Skipped, entry)
+		case controller != nil && controller.Kind == "DaemonSet":
+			entry.Reason = "managed by DaemonSet " + controller.Name
+			result.Skipped = append(result.Skipped, entry)
+		case controller == nil && !finished && !options.Force:
+			entry.Reason = "not managed by a controller, set force to evict it"
+			result.Blocked = append(result.Blocked, entry)
+		case usesEmptyDir(&pod) && !finished && !options.DeleteEmptyDirData:
+			entry.Reason = "uses emptyDir volumes, set deleteEmptyDirData to evict it"
+			result.Blocked = append(result.Blocked, entry)
+		default:
+			evict = append(evict, pod)
+			result.Evicted = append(result.Evicted, entry)
+		}
+	}
+	sortDrainPods(result.Evicted)
+	sortDrainPods(result.Skipped)
+	sortDrainPods(result.Blocked)
+
+	if options.DryRun {
+		return result, nil
+	}
+	if len(result.Blocked) > 0 {
+		var blocked []string
+		for _, pod := range result.Blocked {
+			blocked = append(blocked, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, pod.Reason))
+		}
+		return nil, fmt.Errorf("cannot drain node %s: %s", name, strings.Join(blocked, "; "))
+	}
+
+	if err := setUnschedulable(ctx, kubernetesClient, name, true); err != nil {
+		return nil, err
+	}
+
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Like kubectl drain, all pods are evicted concurrently so a slow termination or a PodDisruptionBudget
+	// blocking one pod does not hold up the others.
+	errs := make([]error, len(evict))
+	var wg sync.WaitGroup
+	for i := range evict {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = evictPod(ctx, kubernetesClient, &evict[i])
+		}()
+	}
+	wg.Wait()
+
+	result.Evicted = []drainPod{}
+	for i, pod := range evict {
+		entry := drainPod{Namespace: pod.Namespace, Name: pod.Name}
+		if errs[i] != nil {
+			entry.Reason = errs[i].Error()
+			result.Failed = append(result.Failed, entry)
+			continue
+		}
+		result.Evicted = append(result.Evicted, entry)
+	}
+	return result, nil
+}
+
+// evictPod evicts pod, retrying while a PodDisruptionBudget forbids it, and waits for the pod to be gone
+// until ctx is done.
+func evictPod(ctx context.Context, kubernetesClient kubernetes.Interface, pod *corev1.Pod) error {
+	podInterface := kubernetesClient.CoreV1().Pods(pod.Namespace)
+	err := wait.PollUntilContextCancel(ctx, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+		err := podInterface.EvictV1(ctx, &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		})
+		switch {
+		case err == nil, apierrors.IsNotFound(err):
+			return true, nil
+		case apierrors.IsTooManyRequests(err):
+			// The eviction would violate a PodDisruptionBudget; try again later.
+			return false, nil
+		}
+		return false, err
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out waiting for eviction, a PodDisruptionBudget may be blocking it")
+	}
+	if err != nil {
+		return err
+	}
+
+	err = wait.PollUntilContextCancel(ctx, 2*time.Second, true, func(ctx context.Context) (bool, error) {
+		current, err := podInterface.Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return current.UID != pod.UID, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("evicted but timed out waiting for the pod to terminate")
+	}
+	return err
+}
+
+func usesEmptyDir(pod *corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func sortDrainPods(pods []drainPod) {
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+}
@@ -0,0 +1,256 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/kubernetes/typed/policy/v1"
+)
+
+// pdbStatus is the disruption health of a PodDisruptionBudget and the pods it covers.
+type pdbStatus struct {
+	Name               string   `json:"name"`
+	Namespace          string   `json:"namespace"`
+	Selector           string   `json:"selector"`
+	MinAvailable       string   `json:"minAvailable,omitempty"`
+	MaxUnavailable     string   `json:"maxUnavailable,omitempty"`
+	Pods               []string `json:"pods"`
+	ExpectedPods       int32    `json:"expectedPods"`
+	CurrentHealthy     int32    `json:"currentHealthy"`
+	DesiredHealthy     int32    `json:"desiredHealthy"`
+	DisruptionsAllowed int32    `json:"disruptionsAllowed"`
+	// Blocking is set when no pod covered by the PDB can be evicted right now.
+	Blocking bool     `json:"blocking"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+func pdbMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, pdbInterface v1.PodDisruptionBudgetInterface, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
+
+	// Only list and status work across PDBs; the other actions need a single PDB.
+	if action != "list" && action != "status" && (name == "" || namespace == "") {
+		return mcp.NewToolResultError("name and namespace are required for " + action + " action"), nil
+	}
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		pdbJson := []byte(resourceSpec)
+		var pdbSpec policyv1.PodDisruptionBudget
+		if err := json.Unmarshal(pdbJson, &pdbSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		pdb, err := pdbInterface.Create(
+			ctx,
+			&policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      pdbSpec.Labels,
+					Annotations: pdbSpec.Annotations,
+				},
+				Spec: pdbSpec.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdPDBSpec, err := json.Marshal(pdb)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created pdb: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdPDBSpec), nil
+	case "delete":
+		err := pdbInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted pdb " + name + " in namespace " + namespace), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var pdbSpec policyv1.PodDisruptionBudget
+		if err := json.Unmarshal([]byte(resourceSpec), &pdbSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		pdb, err := pdbInterface.Update(
+			ctx,
+			&policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      pdbSpec.Labels,
+					Annotations: pdbSpec.Annotations,
+				},
+				Spec: pdbSpec.Spec,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedPDBSpec, err := json.Marshal(pdb)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated pdb: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedPDBSpec), nil
+	case "get":
+		pdb, err := pdbInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pdbSpec, err := json.Marshal(pdb)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal pdb: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(pdbSpec), nil
+	case "list":
+		pdbs, err := pdbInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var pdbNames []string
+		for _, pdb := range pdbs.Items {
+			pdbNames = append(pdbNames, pdb.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(pdbNames), nil
+	case "status":
+		statuses, err := disruptionBudgetStatuses(ctx, name, namespace, kubernetesClient)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(statuses), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// disruptionBudgetStatuses reports every PDB in namespace (all namespaces when empty), or only the one called name,
+// flagging PDBs that match no pods or that block all voluntary disruptions by configuration or right now.
+func disruptionBudgetStatuses(ctx context.Context, name string, namespace string, kubernetesClient kubernetes.Interface) ([]pdbStatus, error) {
+	var pdbs []policyv1.PodDisruptionBudget
+	if name != "" {
+		pdb, err := kubernetesClient.PolicyV1().PodDisruptionBudgets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		pdbs = append(pdbs, *pdb)
+	} else {
+		list, err := kubernetesClient.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		pdbs = list.Items
+	}
+	sort.Slice(pdbs, func(i, j int) bool {
+		if pdbs[i].Namespace != pdbs[j].Namespace {
+			return pdbs[i].Namespace < pdbs[j].Namespace
+		}
+		return pdbs[i].Name < pdbs[j].Name
+	})
+
+	statuses := []pdbStatus{}
+	for _, pdb := range pdbs {
+		status, err := disruptionBudgetStatus(ctx, &pdb, kubernetesClient)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+	}
+	return statuses, nil
+}
+
+// disruptionBudgetStatus resolves the pods of pdb and explains why it blocks disruptions, if it does.
+func disruptionBudgetStatus(ctx context.Context, pdb *policyv1.PodDisruptionBudget, kubernetesClient kubernetes.Interface) (*pdbStatus, error) {
+	status := &pdbStatus{
+		Name:               pdb.Name,
+		Namespace:          pdb.Namespace,
+		Selector:           "<none>",
+		Pods:               []string{},
+		ExpectedPods:       pdb.Status.ExpectedPods,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+	}
+	if pdb.Spec.MinAvailable != nil {
+		status.MinAvailable = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		status.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+
+	// In policy/v1 a nil selector matches no pods and an empty one matches every pod in the namespace.
+	if pdb.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		status.Selector = selector.String()
+		if selector.Empty() {
+			status.Selector = "<all pods>"
+		}
+		pods, err := kubernetesClient.CoreV1().Pods(pdb.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods.Items {
+			status.Pods = append(status.Pods, pod.Name)
+		}
+		sort.Strings(status.Pods)
+	}
+
+	expected := int32(len(status.Pods))
+	if pdb.Status.ExpectedPods > expected {
+		expected = pdb.Status.ExpectedPods
+	}
+	if len(status.Pods) == 0 {
+		status.Problems = append(status.Problems, "matches no pods")
+	}
+	switch {
+	case pdb.Spec.MaxUnavailable != nil:
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MaxUnavailable, int(expected), true)
+		if err == nil && maxUnavailable == 0 {
+			status.Problems = append(status.Problems, "maxUnavailable "+pdb.Spec.MaxUnavailable.String()+" blocks all voluntary disruptions")
+		}
+	case pdb.Spec.MinAvailable != nil && expected > 0:
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MinAvailable, int(expected), true)
+		if err == nil && minAvailable >= int(expected) {
+			status.Problems = append(status.Problems, fmt.Sprintf("minAvailable %s is not below the %d expected pods, which blocks all voluntary disruptions", pdb.Spec.MinAvailable.String(), expected))
+		}
+	}
+	if pdb.Status.ObservedGeneration < pdb.Generation {
+		status.Problems = append(status.Problems, "status has not observed the latest spec yet")
+	}
+	if len(status.Pods) > 0 && pdb.Status.DisruptionsAllowed == 0 {
+		status.Blocking = true
+		if pdb.Status.CurrentHealthy < pdb.Status.DesiredHealthy {
+			status.Problems = append(status.Problems, fmt.Sprintf("only %d of the %d desired healthy pods are healthy, so no disruption is allowed", pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy))
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+func persistentvolumeMCPResponse(ctx context.Context, name string, action string, resourceSpec string, pvInterface v1.PersistentVolumeInterface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		pvJson := []byte(resourceSpec)
+		var pvSpec corev1.PersistentVolume
+		if err := json.Unmarshal(pvJson, &pvSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		pv, err := pvInterface.Create(
+			ctx,
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Labels:      pvSpec.Labels,
+					Annotations: pvSpec.Annotations,
+				},
+				Spec: pvSpec.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdPVSpec, err := json.Marshal(pv)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created persistentvolume: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdPVSpec), nil
+	case "delete":
+		err := pvInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted persistentvolume " + name), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var pvSpec corev1.PersistentVolume
+		if err := json.Unmarshal([]byte(resourceSpec), &pvSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		pv, err := pvInterface.Update(
+			ctx,
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Labels:      pvSpec.Labels,
+					Annotations: pvSpec.Annotations,
+				},
+				Spec: pvSpec.Spec,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedPVSpec, err := json.Marshal(pv)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated persistentvolume: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedPVSpec), nil
+	case "get":
+		pv, err := pvInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pvSpec, err := json.Marshal(pv)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal persistentvolume: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(pvSpec), nil
+	case "list":
+		pvs, err := pvInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var pvNames []string
+		for _, pv := range pvs.Items {
+			pvNames = append(pvNames, pv.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(pvNames), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	storagev1 "k8s.io/client-go/kubernetes/typed/storage/v1"
+)
+
+// resizeResult reports a requested volume expansion. The capacity only changes once the storage
+// driver, and for some drivers the kubelet, has finished resizing the volume.
+type resizeResult struct {
+	Name         string                                  `json:"name"`
+	StorageClass string                                  `json:"storageClass"`
+	OldRequest   string                                  `json:"oldRequest"`
+	NewRequest   string                                  `json:"newRequest"`
+	Capacity     string                                  `json:"capacity,omitempty"`
+	Conditions   []corev1.PersistentVolumeClaimCondition `json:"conditions,omitempty"`
+}
+
+func persistentvolumeclaimMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, size string, pvcInterface v1.PersistentVolumeClaimInterface, storageClassInterface storagev1.StorageClassInterface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		pvcJson := []byte(resourceSpec)
+		var pvcSpec corev1.PersistentVolumeClaim
+		if err := json.Unmarshal(pvcJson, &pvcSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		pvc, err := pvcInterface.Create(
+			ctx,
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      pvcSpec.Labels,
+					Annotations: pvcSpec.Annotations,
+				},
+				Spec: pvcSpec.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdPVCSpec, err := json.Marshal(pvc)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created persistentvolumeclaim: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdPVCSpec), nil
+	case "delete":
+		err := pvcInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted persistentvolumeclaim " + name + " in namespace " + namespace), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var pvcSpec corev1.PersistentVolumeClaim
+		if err := json.Unmarshal([]byte(resourceSpec), &pvcSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		pvc, err := pvcInterface.Update(
+			ctx,
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      pvcSpec.Labels,
+					Annotations: pvcSpec.Annotations,
+				},
+				Spec: pvcSpec.Spec,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedPVCSpec, err := json.Marshal(pvc)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated persistentvolumeclaim: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedPVCSpec), nil
+	case "get":
+		pvc, err := pvcInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pvcSpec, err := json.Marshal(pvc)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal persistentvolumeclaim: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(pvcSpec), nil
+	case "list":
+		pvcs, err := pvcInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var pvcNames []string
+		for _, pvc := range pvcs.Items {
+			pvcNames = append(pvcNames, pvc.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(pvcNames), nil
+	case "resize":
+		if size == "" {
+			return mcp.NewToolResultError("size is required for resize action"), nil
+		}
+		newRequest, err := resource.ParseQuantity(size)
+		if err != nil {
+			return mcp.NewToolResultError("Invalid size: " + err.Error()), nil
+		}
+
+		pvc, err := pvcInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		oldRequest := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if newRequest.Cmp(oldRequest) <= 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("New size %s must be larger than the current request %s, volumes cannot shrink", newRequest.String(), oldRequest.String())), nil
+		}
+		if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+			return mcp.NewToolResultError("Persistentvolumeclaim " + name + " has no storage class, so it cannot be expanded"), nil
+		}
+		storageClass, err := storageClassInterface.Get(
+			ctx,
+			*pvc.Spec.StorageClassName,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+			return mcp.NewToolResultError("Storage class " + storageClass.Name + " does not set allowVolumeExpansion, so its volumes cannot be expanded"), nil
+		}
+
+		patch, err := json.Marshal(map[string]any{
+			"spec": map[string]any{"resources": map[string]any{"requests": map[string]any{"storage": newRequest.String()}}},
+		})
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal patch: " + err.Error()), nil
+		}
+		pvc, err = pvcInterface.Patch(
+			ctx,
+			name,
+			types.MergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		result := resizeResult{
+			Name:         pvc.Name,
+			StorageClass: storageClass.Name,
+			OldRequest:   oldRequest.String(),
+			NewRequest:   newRequest.String(),
+			Conditions:   pvc.Status.Conditions,
+		}
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			result.Capacity = capacity.String()
+		}
+		return mcp.NewToolResultStructuredOnly(result), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
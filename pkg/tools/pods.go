@@ -40,6 +40,7 @@ func podMCPResponse(ctx context.Context, name string, namespace string, action s
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		redactPodSpec(&pod.ObjectMeta, &pod.Spec)
 		createdPodSpec, err := json.Marshal(pod)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal created pod: " + err.Error()), nil
@@ -71,6 +72,7 @@ func podMCPResponse(ctx context.Context, name string, namespace string, action s
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&pod.ObjectMeta, &pod.Spec)
 		updatedPodSpec, err := json.Marshal(pod)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal updated pod: " + err.Error()), nil
@@ -85,6 +87,7 @@ func podMCPResponse(ctx context.Context, name string, namespace string, action s
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&pod.ObjectMeta, &pod.Spec)
 		podSpec, err := json.Marshal(pod)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal pod: " + err.Error()), nil
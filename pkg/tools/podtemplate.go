@@ -0,0 +1,227 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const allContainers = "*"
+
+// imageChange describes the image of a single container before and after a set_image action.
+type imageChange struct {
+	Container string `json:"container"`
+	OldImage  string `json:"oldImage"`
+	NewImage  string `json:"newImage"`
+}
+
+// parseImageAssignments turns container=image pairs into a map keyed by container name.
+func parseImageAssignments(images []string) (map[string]string, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf("images is required for set_image action")
+	}
+
+	assignments := make(map[string]string, len(images))
+	for _, assignment := range images {
+		container, image, found := strings.Cut(assignment, "=")
+		container = strings.TrimSpace(container)
+		image = strings.TrimSpace(image)
+		if !found || container == "" || image == "" {
+			return nil, fmt.Errorf("invalid image assignment %q, expected container=image", assignment)
+		}
+		assignments[container] = image
+	}
+	return assignments, nil
+}
+
+// setImagePatch builds a strategic merge patch that only touches the image field of the
+// matching containers in podSpec. path is the location of the pod spec inside the object,
+// e.g. "spec", "template", "spec" for a deployment.
+func setImagePatch(podSpec *corev1.PodSpec, images []string, path ...string) ([]byte, []imageChange, error) {
+	assignments, err := parseImageAssignments(images)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changes []imageChange
+	matched := make(map[string]bool)
+	patchContainers := func(containers []corev1.Container) []map[string]any {
+		var patched []map[string]any
+		for _, container := range containers {
+			image, ok := assignments[container.Name]
+			if ok {
+				matched[container.Name] = true
+			} else if image, ok = assignments[allContainers]; !ok {
+				continue
+			}
+			changes = append(changes, imageChange{
+				Container: container.Name,
+				OldImage:  container.Image,
+				NewImage:  image,
+			})
+			patched = append(patched, map[string]any{"name": container.Name, "image": image})
+		}
+		return patched
+	}
+
+	podSpecPatch := map[string]any{}
+	if containers := patchContainers(podSpec.InitContainers); len(containers) > 0 {
+		podSpecPatch["initContainers"] = containers
+	}
+	if containers := patchContainers(podSpec.Containers); len(containers) > 0 {
+		podSpecPatch["containers"] = containers
+	}
+
+	for container := range assignments {
+		if container != allContainers && !matched[container] {
+			return nil, nil, fmt.Errorf("container %q not found", container)
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil, fmt.Errorf("no containers matched the given images")
+	}
+
+	patch, err := json.Marshal(nestPatch(podSpecPatch, path...))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	return patch, changes, nil
+}
+
+// nestPatch wraps patch in nested objects following path.
+func nestPatch(patch map[string]any, path ...string) map[string]any {
+	for i := len(path) - 1; i >= 0; i-- {
+		patch = map[string]any{path[i]: patch}
+	}
+	return patch
+}
+
+var (
+	envVarSourceFields = []string{"fieldRef", "resourceFieldRef", "configMapKeyRef", "secretKeyRef"}
+	probeFields        = []string{
+		"exec", "httpGet", "tcpSocket", "grpc",
+		"initialDelaySeconds", "timeoutSeconds", "periodSeconds",
+		"successThreshold", "failureThreshold", "terminationGracePeriodSeconds",
+	}
+)
+
+// templateEdit describes the changes an edit_template action applies to a single container.
+type templateEdit struct {
+	Container      string                       `json:"container"`
+	Env            []corev1.EnvVar              `json:"env,omitempty"`
+	RemoveEnv      []string                     `json:"removeEnv,omitempty"`
+	Resources      *corev1.ResourceRequirements `json:"resources,omitempty"`
+	ReadinessProbe *corev1.Probe                `json:"readinessProbe,omitempty"`
+	LivenessProbe  *corev1.Probe                `json:"livenessProbe,omitempty"`
+}
+
+// editTemplatePatch builds a strategic merge patch from the templateEdit JSON in resourceSpec.
+// It returns the patch together with the name of the edited container.
+func editTemplatePatch(podSpec *corev1.PodSpec, resourceSpec string, path ...string) ([]byte, string, error) {
+	if resourceSpec == "" {
+		return nil, "", fmt.Errorf("resourceSpec is required for edit_template action")
+	}
+
+	var edit templateEdit
+	if err := json.Unmarshal([]byte(resourceSpec), &edit); err != nil {
+		return nil, "", fmt.Errorf("invalid resourceSpec JSON: %w", err)
+	}
+
+	if edit.Container == "" {
+		if len(podSpec.Containers) != 1 {
+			return nil, "", fmt.Errorf("container is required when the pod template has more than one container")
+		}
+		edit.Container = podSpec.Containers[0].Name
+	}
+	if findContainer(podSpec, edit.Container) == nil {
+		return nil, "", fmt.Errorf("container %q not found", edit.Container)
+	}
+
+	containerPatch := map[string]any{"name": edit.Container}
+
+	var env []any
+	updated := make(map[string]bool)
+	for _, envVar := range edit.Env {
+		if envVar.Name == "" {
+			return nil, "", fmt.Errorf("env entries require a name")
+		}
+		updated[envVar.Name] = true
+		// Clear the field that is not being set so switching between value and valueFrom works.
+		if envVar.ValueFrom != nil {
+			valueFrom, err := replacingPatch(envVar.ValueFrom, envVarSourceFields...)
+			if err != nil {
+				return nil, "", err
+			}
+			env = append(env, map[string]any{"name": envVar.Name, "value": nil, "valueFrom": valueFrom})
+		} else {
+			env = append(env, map[string]any{"name": envVar.Name, "value": envVar.Value, "valueFrom": nil})
+		}
+	}
+	for _, name := range edit.RemoveEnv {
+		if updated[name] {
+			return nil, "", fmt.Errorf("env %q is both set and removed", name)
+		}
+		env = append(env, map[string]any{"name": name, "$patch": "delete"})
+	}
+	if len(env) > 0 {
+		containerPatch["env"] = env
+	}
+
+	if edit.Resources != nil {
+		containerPatch["resources"] = edit.Resources
+	}
+	for field, probe := range map[string]*corev1.Probe{
+		"readinessProbe": edit.ReadinessProbe,
+		"livenessProbe":  edit.LivenessProbe,
+	} {
+		if probe == nil {
+			continue
+		}
+		probePatch, err := replacingPatch(probe, probeFields...)
+		if err != nil {
+			return nil, "", err
+		}
+		containerPatch[field] = probePatch
+	}
+
+	if len(containerPatch) == 1 {
+		return nil, "", fmt.Errorf("resourceSpec contains no edits")
+	}
+
+	patch, err := json.Marshal(nestPatch(map[string]any{"containers": []any{containerPatch}}, path...))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	return patch, edit.Container, nil
+}
+
+// replacingPatch converts value to a patch map that clears every field in fields which value
+// does not set, so the patch replaces the existing object instead of merging into it.
+func replacingPatch(value any, fields ...string) (map[string]any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	var patch map[string]any
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	for _, field := range fields {
+		if _, ok := patch[field]; !ok {
+			patch[field] = nil
+		}
+	}
+	return patch, nil
+}
+
+// findContainer returns the container with the given name, or nil if the pod spec has none.
+func findContainer(podSpec *corev1.PodSpec, name string) *corev1.Container {
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == name {
+			return &podSpec.Containers[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,448 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// quotaRequiredResources are the compute resources every container must request or limit once a quota tracks them.
+var quotaRequiredResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// quotaUsageReport is the usage of the ResourceQuotas in a namespace.
+type quotaUsageReport struct {
+	Namespace string               `json:"namespace"`
+	Quotas    []resourceQuotaUsage `json:"quotas"`
+}
+
+type resourceQuotaUsage struct {
+	Quota     string               `json:"quota"`
+	Scopes    []string             `json:"scopes,omitempty"`
+	Resources []quotaResourceUsage `json:"resources"`
+}
+
+type quotaResourceUsage struct {
+	Resource string  `json:"resource"`
+	Used     string  `json:"used"`
+	Hard     string  `json:"hard"`
+	Percent  float64 `json:"percent"`
+	// Requested and After are only set when predicting a pod, with what the pod would add and the resulting usage.
+	Requested string `json:"requested,omitempty"`
+	After     string `json:"after,omitempty"`
+	Exceeded  bool   `json:"exceeded,omitempty"`
+}
+
+// podFitPrediction tells whether a pod would be admitted in a namespace, after applying the LimitRange defaults
+// and checking the LimitRange constraints and the ResourceQuotas that apply to it.
+type podFitPrediction struct {
+	Namespace  string               `json:"namespace"`
+	Fits       bool                 `json:"fits"`
+	Containers []containerResources `json:"containers"`
+	// Charge is what the pod counts against the quotas.
+	Charge   corev1.ResourceList  `json:"charge"`
+	Quotas   []resourceQuotaUsage `json:"quotas"`
+	Problems []string             `json:"problems,omitempty"`
+}
+
+type containerResources struct {
+	Name      string              `json:"name"`
+	Requests  corev1.ResourceList `json:"requests,omitempty"`
+	Limits    corev1.ResourceList `json:"limits,omitempty"`
+	Defaulted []string            `json:"defaulted,omitempty"`
+}
+
+func quotaUsageMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "report":
+		quotas, err := namespaceQuotas(ctx, kubernetesClient, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		report := &quotaUsageReport{Namespace: namespace, Quotas: []resourceQuotaUsage{}}
+		for _, quota := range quotas {
+			report.Quotas = append(report.Quotas, usageOfQuota(&quota, nil))
+		}
+		return mcp.NewToolResultStructuredOnly(report), nil
+	case "predict":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for predict action"), nil
+		}
+		var pod corev1.Pod
+		if err := json.Unmarshal([]byte(resourceSpec), &pod); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		// Accept a bare pod spec as well as a pod.
+		if len(pod.Spec.Containers) == 0 {
+			if err := json.Unmarshal([]byte(resourceSpec), &pod.Spec); err != nil {
+				return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+			}
+		}
+		if len(pod.Spec.Containers) == 0 {
+			return mcp.NewToolResultError("resourceSpec must be a pod or pod spec with at least one container"), nil
+		}
+		prediction, err := predictPodFit(ctx, kubernetesClient, namespace, name, &pod)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(prediction), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// namespaceQuotas returns the ResourceQuotas of namespace, or only the one called name when it is set.
+func namespaceQuotas(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, name string) ([]corev1.ResourceQuota, error) {
+	if name != "" {
+		quota, err := kubernetesClient.CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return []corev1.ResourceQuota{*quota}, nil
+	}
+	quotas, err := kubernetesClient.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(quotas.Items, func(i, j int) bool { return quotas.Items[i].Name < quotas.Items[j].Name })
+	return quotas.Items, nil
+}
+
+// usageOfQuota reports used vs hard for each resource of quota, adding charge when it is not nil.
+// Resources are sorted by how close they are to their limit.
+func usageOfQuota(quota *corev1.ResourceQuota, charge corev1.ResourceList) resourceQuotaUsage {
+	usage := resourceQuotaUsage{Quota: quota.Name, Resources: []quotaResourceUsage{}}
+	for _, scope := range quota.Spec.Scopes {
+		usage.Scopes = append(usage.Scopes, string(scope))
+	}
+	if quota.Spec.ScopeSelector != nil {
+		for _, requirement := range quota.Spec.ScopeSelector.MatchExpressions {
+			usage.Scopes = append(usage.Scopes, fmt.Sprintf("%s %s %s", requirement.ScopeName, requirement.Operator, strings.Join(requirement.Values, ",")))
+		}
+	}
+
+	hard := quota.Status.Hard
+	if len(hard) == 0 {
+		// The quota controller has not synced the status yet.
+		hard = quota.Spec.Hard
+	}
+	for _, resourceName := range sortedKeys(hard) {
+		hardQuantity := hard[resourceName]
+		used := quota.Status.Used[resourceName]
+		entry := quotaResourceUsage{
+			Resource: string(resourceName),
+			Used:     used.String(),
+			Hard:     hardQuantity.String(),
+			Percent:  usagePercent(used, hardQuantity),
+		}
+		if charge != nil {
+			if requested, ok := charge[resourceName]; ok {
+				after := used.DeepCopy()
+				after.Add(requested)
+				entry.Requested = requested.String()
+				entry.After = after.String()
+				entry.Percent = usagePercent(after, hardQuantity)
+				entry.Exceeded = after.Cmp(hardQuantity) > 0
+			}
+		}
+		usage.Resources = append(usage.Resources, entry)
+	}
+	sort.SliceStable(usage.Resources, func(i, j int) bool { return usage.Resources[i].Percent > usage.Resources[j].Percent })
+	return usage
+}
+
+func usagePercent(used resource.Quantity, hard resource.Quantity) float64 {
+	if hard.IsZero() {
+		if used.IsZero() {
+			return 0
+		}
+		return 100
+	}
+	return math.Round(used.AsApproximateFloat64()/hard.AsApproximateFloat64()*1000) / 10
+}
+
+// predictPodFit applies the LimitRange defaults of namespace to pod like the LimitRanger admission plugin, checks
+// the LimitRange constraints and adds the pod's charge to every quota whose scopes select it.
+func predictPodFit(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, quotaName string, pod *corev1.Pod) (*podFitPrediction, error) {
+	limitRanges, err := kubernetesClient.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	quotas, err := namespaceQuotas(ctx, kubernetesClient, namespace, quotaName)
+	if err != nil {
+		return nil, err
+	}
+
+	prediction := &podFitPrediction{Namespace: namespace, Quotas: []resourceQuotaUsage{}}
+	prediction.Containers = applyLimitRangeDefaults(&pod.Spec, limitRanges.Items)
+	prediction.Problems = checkLimitRanges(&pod.Spec, limitRanges.Items)
+	prediction.Charge = podQuotaCharge(&pod.Spec)
+
+	for _, quota := range quotas {
+		if !quotaSelectsPod(&quota, pod) {
+			continue
+		}
+		usage := usageOfQuota(&quota, prediction.Charge)
+		for _, entry := range usage.Resources {
+			if entry.Exceeded {
+				prediction.Problems = append(prediction.Problems, fmt.Sprintf("quota %s: %s would be %s, over the hard limit of %s", quota.Name, entry.Resource, entry.After, entry.Hard))
+			}
+		}
+		prediction.Problems = append(prediction.Problems, missingQuotaResources(&quota, &pod.Spec)...)
+		prediction.Quotas = append(prediction.Quotas, usage)
+	}
+	prediction.Fits = len(prediction.Problems) == 0
+	return prediction, nil
+}
+
+// applyLimitRangeDefaults fills in missing requests and limits of the containers of podSpec. Requests first default
+// to the container's own limits, as the API server does, then to the LimitRange defaultRequest; limits to its default.
+func applyLimitRangeDefaults(podSpec *corev1.PodSpec, limitRanges []corev1.LimitRange) []containerResources {
+	var result []containerResources
+	apply := func(container *corev1.Container) {
+		resources := &container.Resources
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		if resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		var defaulted []string
+		for resourceName, limit := range resources.Limits {
+			if _, ok := resources.Requests[resourceName]; !ok {
+				resources.Requests[resourceName] = limit.DeepCopy()
+			}
+		}
+		for _, limitRange := range limitRanges {
+			for _, item := range limitRange.Spec.Limits {
+				if item.Type != corev1.LimitTypeContainer {
+					continue
+				}
+				for _, resourceName := range sortedKeys(item.Default) {
+					if _, ok := resources.Limits[resourceName]; !ok {
+						limit := item.Default[resourceName]
+						resources.Limits[resourceName] = limit.DeepCopy()
+						defaulted = append(defaulted, fmt.Sprintf("limits.%s=%s from LimitRange %s", resourceName, limit.String(), limitRange.Name))
+					}
+				}
+				for _, resourceName := range sortedKeys(item.DefaultRequest) {
+					if _, ok := resources.Requests[resourceName]; !ok {
+						request := item.DefaultRequest[resourceName]
+						resources.Requests[resourceName] = request.DeepCopy()
+						defaulted = append(defaulted, fmt.Sprintf("requests.%s=%s from LimitRange %s", resourceName, request.String(), limitRange.Name))
+					}
+				}
+			}
+		}
+		result = append(result, containerResources{
+			Name:      container.Name,
+			Requests:  resources.Requests,
+			Limits:    resources.Limits,
+			Defaulted: defaulted,
+		})
+	}
+	for i := range podSpec.InitContainers {
+		apply(&podSpec.InitContainers[i])
+	}
+	for i := range podSpec.Containers {
+		apply(&podSpec.Containers[i])
+	}
+	return result
+}
+
+// checkLimitRanges returns the violations of the min, max and maxLimitRequestRatio constraints of the
+// Container and Pod items of limitRanges.
+func checkLimitRanges(podSpec *corev1.PodSpec, limitRanges []corev1.LimitRange) []string {
+	var problems []string
+	check := func(limitRange string, item corev1.LimitRangeItem, subject string, requests corev1.ResourceList, limits corev1.ResourceList) {
+		for _, resourceName := range sortedKeys(item.Min) {
+			minimum := item.Min[resourceName]
+			request, ok := requests[resourceName]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("LimitRange %s: minimum %s per %s is %s, but no request is set", limitRange, resourceName, item.Type, minimum.String()))
+			} else if request.Cmp(minimum) < 0 {
+				problems = append(problems, fmt.Sprintf("LimitRange %s: minimum %s per %s is %s, but %s requests %s", limitRange, resourceName, item.Type, minimum.String(), subject, request.String()))
+			}
+		}
+		for _, resourceName := range sortedKeys(item.Max) {
+			maximum := item.Max[resourceName]
+			limit, ok := limits[resourceName]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("LimitRange %s: maximum %s per %s is %s, but no limit is set", limitRange, resourceName, item.Type, maximum.String()))
+			} else if limit.Cmp(maximum) > 0 {
+				problems = append(problems, fmt.Sprintf("LimitRange %s: maximum %s per %s is %s, but %s limits it to %s", limitRange, resourceName, item.Type, maximum.String(), subject, limit.String()))
+			}
+		}
+		for _, resourceName := range sortedKeys(item.MaxLimitRequestRatio) {
+			ratio := item.MaxLimitRequestRatio[resourceName]
+			request, hasRequest := requests[resourceName]
+			limit, hasLimit := limits[resourceName]
+			if !hasRequest || !hasLimit || request.IsZero() {
+				continue
+			}
+			if actual := limit.AsApproximateFloat64() / request.AsApproximateFloat64(); actual > ratio.AsApproximateFloat64() {
+				problems = append(problems, fmt.Sprintf("LimitRange %s: maximum %s limit to request ratio per %s is %s, but %s has %.2f", limitRange, resourceName, item.Type, ratio.String(), subject, actual))
+			}
+		}
+	}
+
+	requests, limits := podResources(podSpec)
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			switch item.Type {
+			case corev1.LimitTypeContainer:
+				for _, container := range append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...) {
+					check(limitRange.Name, item, "container "+container.Name, container.Resources.Requests, container.Resources.Limits)
+				}
+			case corev1.LimitTypePod:
+				check(limitRange.Name, item, "the pod", requests, limits)
+			}
+		}
+	}
+	return problems
+}
+
+// podResources returns the effective requests and limits of a pod: the larger of the sum over its containers and
+// the largest init container, plus the pod overhead.
+func podResources(podSpec *corev1.PodSpec) (corev1.ResourceList, corev1.ResourceList) {
+	total := func(list func(corev1.Container) corev1.ResourceList) corev1.ResourceList {
+		result := corev1.ResourceList{}
+		for _, container := range podSpec.Containers {
+			for resourceName, quantity := range list(container) {
+				sum := result[resourceName]
+				sum.Add(quantity)
+				result[resourceName] = sum
+			}
+		}
+		for _, container := range podSpec.InitContainers {
+			for resourceName, quantity := range list(container) {
+				if current, ok := result[resourceName]; !ok || quantity.Cmp(current) > 0 {
+					result[resourceName] = quantity.DeepCopy()
+				}
+			}
+		}
+		for resourceName, quantity := range podSpec.Overhead {
+			sum := result[resourceName]
+			sum.Add(quantity)
+			result[resourceName] = sum
+		}
+		return result
+	}
+	requests := total(func(container corev1.Container) corev1.ResourceList { return container.Resources.Requests })
+	limits := total(func(container corev1.Container) corev1.ResourceList { return container.Resources.Limits })
+	return requests, limits
+}
+
+// podQuotaCharge returns what a pod counts against quota, keyed like the hard limits of a ResourceQuota.
+func podQuotaCharge(podSpec *corev1.PodSpec) corev1.ResourceList {
+	requests, limits := podResources(podSpec)
+	charge := corev1.ResourceList{
+		corev1.ResourcePods:               resource.MustParse("1"),
+		corev1.ResourceName("count/pods"): resource.MustParse("1"),
+	}
+	for resourceName, quantity := range requests {
+		charge[corev1.ResourceName("requests."+string(resourceName))] = quantity
+		// cpu, memory and ephemeral-storage are also tracked without the requests prefix.
+		if resourceName == corev1.ResourceCPU || resourceName == corev1.ResourceMemory || resourceName == corev1.ResourceEphemeralStorage {
+			charge[resourceName] = quantity
+		}
+	}
+	for resourceName, quantity := range limits {
+		charge[corev1.ResourceName("limits."+string(resourceName))] = quantity
+	}
+	return charge
+}
+
+// missingQuotaResources returns the containers the quota admission would reject because quota tracks a compute
+// resource they neither request nor limit.
+func missingQuotaResources(quota *corev1.ResourceQuota, podSpec *corev1.PodSpec) []string {
+	var problems []string
+	for _, resourceName := range quotaRequiredResources {
+		for _, prefix := range []string{"", "requests.", "limits."} {
+			tracked := corev1.ResourceName(prefix + string(resourceName))
+			if _, ok := quota.Spec.Hard[tracked]; !ok {
+				continue
+			}
+			for _, container := range append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...) {
+				list := container.Resources.Requests
+				if prefix == "limits." {
+					list = container.Resources.Limits
+				}
+				if _, ok := list[resourceName]; !ok {
+					problems = append(problems, fmt.Sprintf("quota %s tracks %s, but container %s does not set it", quota.Name, tracked, container.Name))
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// quotaSelectsPod reports whether the scopes and scope selector of quota select pod.
+func quotaSelectsPod(quota *corev1.ResourceQuota, pod *corev1.Pod) bool {
+	var requirements []corev1.ScopedResourceSelectorRequirement
+	for _, scope := range quota.Spec.Scopes {
+		requirements = append(requirements, corev1.ScopedResourceSelectorRequirement{ScopeName: scope, Operator: corev1.ScopeSelectorOpExists})
+	}
+	if quota.Spec.ScopeSelector != nil {
+		requirements = append(requirements, quota.Spec.ScopeSelector.MatchExpressions...)
+	}
+	for _, requirement := range requirements {
+		matches, err := podMatchesScope(requirement, pod)
+		if err != nil || !matches {
+			return false
+		}
+	}
+	return true
+}
+
+func podMatchesScope(requirement corev1.ScopedResourceSelectorRequirement, pod *corev1.Pod) (bool, error) {
+	switch requirement.ScopeName {
+	case corev1.ResourceQuotaScopeTerminating:
+		return pod.Spec.ActiveDeadlineSeconds != nil && *pod.Spec.ActiveDeadlineSeconds >= 0, nil
+	case corev1.ResourceQuotaScopeNotTerminating:
+		return pod.Spec.ActiveDeadlineSeconds == nil || *pod.Spec.ActiveDeadlineSeconds < 0, nil
+	case corev1.ResourceQuotaScopeBestEffort:
+		return isBestEffort(&pod.Spec), nil
+	case corev1.ResourceQuotaScopeNotBestEffort:
+		return !isBestEffort(&pod.Spec), nil
+	case corev1.ResourceQuotaScopePriorityClass:
+		priorityClass := pod.Spec.PriorityClassName
+		switch requirement.Operator {
+		case corev1.ScopeSelectorOpExists:
+			return priorityClass != "", nil
+		case corev1.ScopeSelectorOpDoesNotExist:
+			return priorityClass == "", nil
+		case corev1.ScopeSelectorOpIn, corev1.ScopeSelectorOpNotIn:
+			found := false
+			for _, value := range requirement.Values {
+				found = found || value == priorityClass
+			}
+			return found == (requirement.Operator == corev1.ScopeSelectorOpIn), nil
+		}
+	}
+	return false, errors.New("unsupported quota scope " + string(requirement.ScopeName))
+}
+
+// isBestEffort reports whether no container of podSpec requests or limits cpu or memory.
+func isBestEffort(podSpec *corev1.PodSpec) bool {
+	for _, container := range append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...) {
+		for _, resourceName := range quotaRequiredResources {
+			if _, ok := container.Resources.Requests[resourceName]; ok {
+				return false
+			}
+			if _, ok := container.Resources.Limits[resourceName]; ok {
+				return false
+			}
+		}
+	}
+	return true
+}
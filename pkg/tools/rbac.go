@@ -0,0 +1,416 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+// accessQuery is the request an rbac action checks. Resource is given like kubectl auth can-i, as
+// resource[.group][/subresource], or as a non-resource URL starting with a slash.
+type accessQuery struct {
+	Verb           string
+	Resource       string
+	ResourceName   string
+	Namespace      string
+	User           string
+	Groups         []string
+	ServiceAccount string
+}
+
+// accessResource is the resource of an accessQuery resolved to its API group.
+type accessResource struct {
+	Group          string
+	Resource       string
+	Subresource    string
+	NonResourceURL string
+}
+
+func (r accessResource) String() string {
+	if r.NonResourceURL != "" {
+		return r.NonResourceURL
+	}
+	resource := r.Resource
+	if r.Group != "" {
+		resource += "." + r.Group
+	}
+	if r.Subresource != "" {
+		resource += "/" + r.Subresource
+	}
+	return resource
+}
+
+// accessReview is the answer to a can-i question.
+type accessReview struct {
+	Subject   string `json:"subject"`
+	Verb      string `json:"verb"`
+	Resource  string `json:"resource"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Allowed   bool   `json:"allowed"`
+	// Denied is set when an authorizer explicitly denied the request, rather than no authorizer allowing it.
+	Denied          bool   `json:"denied,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	EvaluationError string `json:"evaluationError,omitempty"`
+}
+
+// accessRules is the list of rules the caller has in a namespace.
+type accessRules struct {
+	Namespace        string                            `json:"namespace"`
+	ResourceRules    []authorizationv1.ResourceRule    `json:"resourceRules"`
+	NonResourceRules []authorizationv1.NonResourceRule `json:"nonResourceRules,omitempty"`
+	// Incomplete is set when an authorizer could not list its rules, e.g. a webhook authorizer.
+	Incomplete      bool   `json:"incomplete,omitempty"`
+	EvaluationError string `json:"evaluationError,omitempty"`
+}
+
+// whoCan lists the subjects bound to a role that grants the queried permission.
+type whoCan struct {
+	Verb      string          `json:"verb"`
+	Resource  string          `json:"resource"`
+	Name      string          `json:"name,omitempty"`
+	Namespace string          `json:"namespace,omitempty"`
+	Subjects  []whoCanSubject `json:"subjects"`
+	Notes     []string        `json:"notes,omitempty"`
+}
+
+type whoCanSubject struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Scope is "cluster" for ClusterRoleBindings, otherwise the namespace of the RoleBinding.
+	Scope string `json:"scope"`
+	// Via is the binding and role granting the permission, e.g. RoleBinding/dev-edit -> ClusterRole/edit.
+	Via string `json:"via"`
+}
+
+func rbacMCPResponse(ctx context.Context, action string, query accessQuery, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "can-i":
+		review, err := canI(ctx, kubernetesClient, query)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(review), nil
+	case "rules":
+		namespace := query.Namespace
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		review, err := kubernetesClient.AuthorizationV1().SelfSubjectRulesReviews().Create(
+			ctx,
+			&authorizationv1.SelfSubjectRulesReview{
+				Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(&accessRules{
+			Namespace:        namespace,
+			ResourceRules:    review.Status.ResourceRules,
+			NonResourceRules: review.Status.NonResourceRules,
+			Incomplete:       review.Status.Incomplete,
+			EvaluationError:  review.Status.EvaluationError,
+		}), nil
+	case "who-can":
+		result, err := whoCanAccess(ctx, kubernetesClient, query)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(result), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// canI asks the API server whether the caller, or the user or service account in query, may perform the request.
+func canI(ctx context.Context, kubernetesClient kubernetes.Interface, query accessQuery) (*accessReview, error) {
+	if query.Verb == "" || query.Resource == "" {
+		return nil, errors.New("verb and resource are required for can-i action")
+	}
+	resource := resolveAccessResource(kubernetesClient.Discovery(), query.Resource)
+	var resourceAttributes *authorizationv1.ResourceAttributes
+	var nonResourceAttributes *authorizationv1.NonResourceAttributes
+	if resource.NonResourceURL != "" {
+		nonResourceAttributes = &authorizationv1.NonResourceAttributes{Path: resource.NonResourceURL, Verb: query.Verb}
+	} else {
+		resourceAttributes = &authorizationv1.ResourceAttributes{
+			Namespace:   query.Namespace,
+			Verb:        query.Verb,
+			Group:       resource.Group,
+			Resource:    resource.Resource,
+			Subresource: resource.Subresource,
+			Name:        query.ResourceName,
+		}
+	}
+
+	result := &accessReview{
+		Subject:   "(current user)",
+		Verb:      query.Verb,
+		Resource:  resource.String(),
+		Name:      query.ResourceName,
+		Namespace: query.Namespace,
+	}
+	user, groups := query.User, query.Groups
+	if query.ServiceAccount != "" {
+		user, groups = serviceAccountUser(query.ServiceAccount, query.Namespace)
+	}
+
+	var status authorizationv1.SubjectAccessReviewStatus
+	if user == "" && len(groups) == 0 {
+		review, err := kubernetesClient.AuthorizationV1().SelfSubjectAccessReviews().Create(
+			ctx,
+			&authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes:    resourceAttributes,
+					NonResourceAttributes: nonResourceAttributes,
+				},
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return nil, err
+		}
+		status = review.Status
+	} else {
+		review, err := kubernetesClient.AuthorizationV1().SubjectAccessReviews().Create(
+			ctx,
+			&authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					ResourceAttributes:    resourceAttributes,
+					NonResourceAttributes: nonResourceAttributes,
+					User:                  user,
+					Groups:                groups,
+				},
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return nil, err
+		}
+		status = review.Status
+		result.Subject = user
+		if user == "" {
+			result.Subject = "groups " + strings.Join(groups, ", ")
+		}
+	}
+	result.Allowed = status.Allowed
+	result.Denied = status.Denied
+	result.Reason = status.Reason
+	result.EvaluationError = status.EvaluationError
+	return result, nil
+}
+
+// serviceAccountUser returns the user name and groups the API server authenticates a service account token as.
+// serviceAccount is namespace:name, or a name in namespace (the default namespace when empty).
+func serviceAccountUser(serviceAccount string, namespace string) (string, []string) {
+	name := serviceAccount
+	if accountNamespace, accountName, found := strings.Cut(serviceAccount, ":"); found {
+		namespace, name = accountNamespace, accountName
+	}
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return "system:serviceaccount:" + namespace + ":" + name, []string{
+		"system:serviceaccounts",
+		"system:serviceaccounts:" + namespace,
+		"system:authenticated",
+	}
+}
+
+// resolveAccessResource splits resource[.group][/subresource] and, when no group is given, looks the
+// resource up by plural, singular or short name through discovery, like kubectl does.
+func resolveAccessResource(discoveryClient discovery.DiscoveryInterface, resource string) accessResource {
+	if strings.HasPrefix(resource, "/") {
+		return accessResource{NonResourceURL: resource}
+	}
+	var result accessResource
+	resource, result.Subresource, _ = strings.Cut(resource, "/")
+	result.Resource, result.Group, _ = strings.Cut(resource, ".")
+	if result.Group != "" || result.Resource == "*" {
+		return result
+	}
+
+	_, resourceLists, err := discoveryClient.ServerGroupsAndResources()
+	// Partial discovery failures are common with broken aggregated APIs; use whatever was discovered.
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return result
+	}
+	for _, resourceList := range resourceLists {
+		for _, apiResource := range resourceList.APIResources {
+			matches := apiResource.Name == result.Resource || apiResource.SingularName == result.Resource
+			for _, shortName := range apiResource.ShortNames {
+				matches = matches || shortName == result.Resource
+			}
+			if !matches {
+				continue
+			}
+			// The core group, listed first, wins when a name exists in several groups.
+			if group, _, found := strings.Cut(resourceList.GroupVersion, "/"); found {
+				result.Group = group
+			}
+			result.Resource = apiResource.Name
+			return result
+		}
+	}
+	return result
+}
+
+// whoCanAccess walks the ClusterRoleBindings and RoleBindings and lists every subject whose role has a rule
+// granting the queried permission. Permissions from other authorizers, such as webhooks or the node
+// authorizer, are not visible to it.
+func whoCanAccess(ctx context.Context, kubernetesClient kubernetes.Interface, query accessQuery) (*whoCan, error) {
+	if query.Verb == "" || query.Resource == "" {
+		return nil, errors.New("verb and resource are required for who-can action")
+	}
+	resource := resolveAccessResource(kubernetesClient.Discovery(), query.Resource)
+	result := &whoCan{
+		Verb:      query.Verb,
+		Resource:  resource.String(),
+		Name:      query.ResourceName,
+		Namespace: query.Namespace,
+		Subjects:  []whoCanSubject{},
+	}
+
+	clusterRoles, err := kubernetesClient.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusterRoleRules := map[string][]rbacv1.PolicyRule{}
+	for _, clusterRole := range clusterRoles.Items {
+		clusterRoleRules[clusterRole.Name] = clusterRole.Rules
+	}
+	clusterRoleBindings, err := kubernetesClient.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	missing := map[string]bool{}
+	for _, binding := range clusterRoleBindings.Items {
+		rules, found := clusterRoleRules[binding.RoleRef.Name]
+		if !found {
+			missing["ClusterRoleBinding/"+binding.Name+" references missing ClusterRole/"+binding.RoleRef.Name] = true
+			continue
+		}
+		if rulesAllow(rules, query.Verb, resource, query.ResourceName) {
+			result.Subjects = appendBindingSubjects(result.Subjects, binding.Subjects, "cluster", "ClusterRoleBinding/"+binding.Name+" -> ClusterRole/"+binding.RoleRef.Name)
+		}
+	}
+
+	// Non-resource URLs can only be granted cluster-wide.
+	if resource.NonResourceURL == "" {
+		roles, err := kubernetesClient.RbacV1().Roles(query.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		roleRules := map[string][]rbacv1.PolicyRule{}
+		for _, role := range roles.Items {
+			roleRules[role.Namespace+"/"+role.Name] = role.Rules
+		}
+		roleBindings, err := kubernetesClient.RbacV1().RoleBindings(query.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, binding := range roleBindings.Items {
+			var rules []rbacv1.PolicyRule
+			var found bool
+			if binding.RoleRef.Kind == "ClusterRole" {
+				rules, found = clusterRoleRules[binding.RoleRef.Name]
+			} else {
+				rules, found = roleRules[binding.Namespace+"/"+binding.RoleRef.Name]
+			}
+			if !found {
+				missing["RoleBinding/"+binding.Name+" in namespace "+binding.Namespace+" references missing "+binding.RoleRef.Kind+"/"+binding.RoleRef.Name] = true
+				continue
+			}
+			if rulesAllow(rules, query.Verb, resource, query.ResourceName) {
+				result.Subjects = appendBindingSubjects(result.Subjects, binding.Subjects, binding.Namespace, "RoleBinding/"+binding.Name+" -> "+binding.RoleRef.Kind+"/"+binding.RoleRef.Name)
+			}
+		}
+	}
+
+	sort.SliceStable(result.Subjects, func(i, j int) bool {
+		if result.Subjects[i].Kind != result.Subjects[j].Kind {
+			return result.Subjects[i].Kind < result.Subjects[j].Kind
+		}
+		if result.Subjects[i].Namespace != result.Subjects[j].Namespace {
+			return result.Subjects[i].Namespace < result.Subjects[j].Namespace
+		}
+		return result.Subjects[i].Name < result.Subjects[j].Name
+	})
+	result.Notes = sortedKeys(missing)
+	return result, nil
+}
+
+func appendBindingSubjects(subjects []whoCanSubject, bindingSubjects []rbacv1.Subject, scope string, via string) []whoCanSubject {
+	for _, subject := range bindingSubjects {
+		subjects = append(subjects, whoCanSubject{
+			Kind:      subject.Kind,
+			Name:      subject.Name,
+			Namespace: subject.Namespace,
+			Scope:     scope,
+			Via:       via,
+		})
+	}
+	return subjects
+}
+
+// rulesAllow reports whether any of rules grants verb on resource, following the matching of the RBAC authorizer.
+func rulesAllow(rules []rbacv1.PolicyRule, verb string, resource accessResource, resourceName string) bool {
+	for _, rule := range rules {
+		if !ruleContains(rule.Verbs, verb) {
+			continue
+		}
+		if resource.NonResourceURL != "" {
+			for _, url := range rule.NonResourceURLs {
+				if url == rbacv1.NonResourceAll || url == resource.NonResourceURL ||
+					(strings.HasSuffix(url, "*") && strings.HasPrefix(resource.NonResourceURL, strings.TrimSuffix(url, "*"))) {
+					return true
+				}
+			}
+			continue
+		}
+		if !ruleContains(rule.APIGroups, resource.Group) || !ruleResourceMatches(rule.Resources, resource) {
+			continue
+		}
+		if len(rule.ResourceNames) == 0 || (resourceName != "" && ruleContains(rule.ResourceNames, resourceName)) {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleContains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value || candidate == rbacv1.VerbAll {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleResourceMatches(resources []string, resource accessResource) bool {
+	combined := resource.Resource
+	if resource.Subresource != "" {
+		combined += "/" + resource.Subresource
+	}
+	for _, candidate := range resources {
+		switch {
+		case candidate == rbacv1.ResourceAll || candidate == combined:
+			return true
+		case resource.Subresource != "" && candidate == rbacv1.ResourceAll+"/"+resource.Subresource:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const redacted = "[REDACTED]"
+
+// lastAppliedAnnotation is written by kubectl apply and contains the full object, including secret values.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// sensitiveEnvNames are substrings of env var names whose literal values are redacted.
+var sensitiveEnvNames = []string{
+	"PASSWORD", "PASSWD", "SECRET", "TOKEN", "APIKEY", "API_KEY",
+	"PRIVATE_KEY", "ACCESS_KEY", "CREDENTIAL", "AUTH",
+}
+
+// redactedSecret is a secret with its values replaced by their length and fingerprint.
+type redactedSecret struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Type              corev1.SecretType      `json:"type,omitempty"`
+	Immutable         *bool                  `json:"immutable,omitempty"`
+	Data              map[string]secretValue `json:"data"`
+	// Warnings lists requested reveal keys that could not be revealed.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type secretValue struct {
+	Length int    `json:"length"`
+	SHA256 string `json:"sha256"`
+	Value  string `json:"value,omitempty"`
+}
+
+// redactSecret replaces the values of secret with fingerprints, except for the keys in reveal.
+func redactSecret(secret *corev1.Secret, reveal []string, allowReveal bool) (*redactedSecret, error) {
+	if len(reveal) > 0 && !allowReveal {
+		return nil, fmt.Errorf("revealing secret values is disabled by server configuration")
+	}
+
+	revealed := make(map[string]bool, len(reveal))
+	for _, key := range reveal {
+		if _, ok := secret.Data[key]; !ok {
+			return nil, fmt.Errorf("key %q not found in secret %s", key, secret.Name)
+		}
+		revealed[key] = true
+	}
+
+	result := &redactedSecret{
+		ObjectMeta: *secret.ObjectMeta.DeepCopy(),
+		Type:       secret.Type,
+		Immutable:  secret.Immutable,
+		Data:       make(map[string]secretValue, len(secret.Data)),
+	}
+	redactObjectMeta(&result.ObjectMeta)
+	for key, value := range secret.Data {
+		sum := sha256.Sum256(value)
+		redactedValue := secretValue{
+			Length: len(value),
+			SHA256: hex.EncodeToString(sum[:]),
+		}
+		if revealed[key] {
+			redactedValue.Value = string(value)
+		}
+		result.Data[key] = redactedValue
+	}
+	return result, nil
+}
+
+// redactPodSpec replaces literal values of sensitive looking env vars in podSpec, and the
+// last-applied-configuration annotation that would otherwise repeat them.
+func redactPodSpec(meta *metav1.ObjectMeta, podSpec *corev1.PodSpec) {
+	redactObjectMeta(meta)
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			redactEnv(containers[i].Env)
+		}
+	}
+}
+
+func redactEnv(env []corev1.EnvVar) {
+	for i := range env {
+		if env[i].Value != "" && sensitiveEnvName(env[i].Name) {
+			env[i].Value = redacted
+		}
+	}
+}
+
+func sensitiveEnvName(name string) bool {
+	name = strings.ToUpper(name)
+	for _, sensitive := range sensitiveEnvNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactObjectMeta(meta *metav1.ObjectMeta) {
+	if _, ok := meta.Annotations[lastAppliedAnnotation]; ok {
+		meta.Annotations[lastAppliedAnnotation] = redacted
+	}
+}
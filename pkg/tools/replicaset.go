@@ -7,10 +7,11 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 )
 
-func replicasetMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, replicasetInterface v1.ReplicaSetInterface) (*mcp.CallToolResult, error) {
+func replicasetMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, images []string, replicasetInterface v1.ReplicaSetInterface) (*mcp.CallToolResult, error) {
 
 	switch action {
 	case "create":
@@ -40,6 +41,7 @@ func replicasetMCPResponse(ctx context.Context, name string, namespace string, a
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		redactPodSpec(&replicaset.ObjectMeta, &replicaset.Spec.Template.Spec)
 		createdReplicaSetSpec, err := json.Marshal(replicaset)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal created replicaset: " + err.Error()), nil
@@ -70,6 +72,7 @@ func replicasetMCPResponse(ctx context.Context, name string, namespace string, a
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&replicaset.ObjectMeta, &replicaset.Spec.Template.Spec)
 		updatedReplicaSetSpec, err := json.Marshal(replicaset)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal updated replicaset: " + err.Error()), nil
@@ -84,6 +87,7 @@ func replicasetMCPResponse(ctx context.Context, name string, namespace string, a
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&replicaset.ObjectMeta, &replicaset.Spec.Template.Spec)
 		replicasetSpec, err := json.Marshal(replicaset)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal replicaset: " + err.Error()), nil
@@ -102,6 +106,55 @@ func replicasetMCPResponse(ctx context.Context, name string, namespace string, a
 			replicasetNames = append(replicasetNames, replicaset.Name)
 		}
 		return mcp.NewToolResultStructuredOnly(replicasetNames), nil
+	case "set_image":
+		replicaset, err := replicasetInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, changes, err := setImagePatch(&replicaset.Spec.Template.Spec, images, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		_, err = replicasetInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(changes), nil
+	case "edit_template":
+		replicaset, err := replicasetInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, container, err := editTemplatePatch(&replicaset.Spec.Template.Spec, resourceSpec, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		replicaset, err = replicasetInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactPodSpec(&replicaset.ObjectMeta, &replicaset.Spec.Template.Spec)
+		return mcp.NewToolResultStructuredOnly(findContainer(&replicaset.Spec.Template.Spec, container)), nil
 	}
 	return mcp.NewToolResultError("Unknown action: " + action), nil
 }
@@ -0,0 +1,318 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+// patchTypes are the patch formats the resource tool accepts. Strategic merge patches are not offered because
+// custom resources do not support them.
+var patchTypes = []string{"merge", "json"}
+
+// resourceType identifies the type of object the resource tool works on: an apiVersion and kind, or a resource
+// name like kubectl accepts (plural, singular or short name, optionally qualified as resource.version.group).
+type resourceType struct {
+	APIVersion string
+	Kind       string
+	Resource   string
+}
+
+func (t resourceType) String() string {
+	if t.Kind != "" {
+		return strings.TrimPrefix(t.APIVersion+" "+t.Kind, " ")
+	}
+	return t.Resource
+}
+
+func resourceMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, resourceType resourceType, patchType string, labelSelector string, reveal []string, allowReveal bool, restMapper meta.RESTMapper, dynamicClient dynamic.Interface) (*mcp.CallToolResult, error) {
+
+	mapping, err := resolveResourceType(restMapper, resourceType)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var resourceInterface dynamic.ResourceInterface = dynamicClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resourceInterface = dynamicClient.Resource(mapping.Resource).Namespace(namespace)
+		if namespace == "" && action != "list" {
+			return mcp.NewToolResultError(mapping.Resource.Resource + " is namespaced, namespace is required for " + action + " action"), nil
+		}
+	}
+	if name == "" && action != "list" && action != "create" {
+		return mcp.NewToolResultError("name is required for " + action + " action"), nil
+	}
+	kindName := strings.ToLower(mapping.GroupVersionKind.Kind)
+	// Check reveal before changing anything, the secret values are only redacted in the response.
+	if len(reveal) > 0 {
+		if mapping.GroupVersionKind.GroupKind() != (schema.GroupKind{Kind: secretKind}) {
+			return mcp.NewToolResultError("reveal is only supported for secrets"), nil
+		}
+		if !allowReveal {
+			return mcp.NewToolResultError("revealing secret values is disabled by server configuration"), nil
+		}
+	}
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		var object unstructured.Unstructured
+		if err := json.Unmarshal([]byte(resourceSpec), &object.Object); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		object.SetAPIVersion(mapping.GroupVersionKind.GroupVersion().String())
+		object.SetKind(mapping.GroupVersionKind.Kind)
+		if name != "" {
+			object.SetName(name)
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			object.SetNamespace(namespace)
+		}
+
+		created, err := resourceInterface.Create(
+			ctx,
+			&object,
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		redactedObject, err := redactResource(created, reveal, allowReveal)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		createdResourceSpec, err := json.Marshal(redactedObject)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created " + kindName + ": " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdResourceSpec), nil
+	case "delete":
+		err := resourceInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			return mcp.NewToolResultText("Deleted " + kindName + " " + name + " in namespace " + namespace), nil
+		}
+		return mcp.NewToolResultText("Deleted " + kindName + " " + name), nil
+	case "patch", "apply":
+		var patched *unstructured.Unstructured
+		if action == "patch" {
+			if resourceSpec == "" {
+				return mcp.NewToolResultError("resourceSpec is required for patch action"), nil
+			}
+			patchFormat := types.MergePatchType
+			if patchType == "json" {
+				patchFormat = types.JSONPatchType
+			}
+			patched, err = resourceInterface.Patch(
+				ctx,
+				name,
+				patchFormat,
+				[]byte(resourceSpec),
+				metav1.PatchOptions{},
+			)
+		} else {
+			var patch []byte
+			objectNamespace := ""
+			if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+				objectNamespace = namespace
+			}
+			if patch, err = applyPatch(resourceSpec, mapping.GroupVersionKind.GroupVersion().String(), mapping.GroupVersionKind.Kind, name, objectNamespace); err == nil {
+				patched, err = resourceInterface.Patch(
+					ctx,
+					name,
+					types.ApplyPatchType,
+					patch,
+					metav1.PatchOptions{FieldManager: fieldManager, Force: ptr.To(true)},
+				)
+			}
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactedObject, err := redactResource(patched, reveal, allowReveal)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patchedResourceSpec, err := json.Marshal(redactedObject)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal patched " + kindName + ": " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(patchedResourceSpec), nil
+	case "get":
+		object, err := resourceInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactedObject, err := redactResource(object, reveal, allowReveal)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		objectSpec, err := json.Marshal(redactedObject)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal " + kindName + ": " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(objectSpec), nil
+	case "list":
+		objects, err := resourceInterface.List(
+			ctx,
+			metav1.ListOptions{LabelSelector: labelSelector},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		// Objects listed across all namespaces are qualified with their namespace.
+		var objectNames []string
+		for _, object := range objects.Items {
+			if object.GetNamespace() != "" && namespace == "" {
+				objectNames = append(objectNames, object.GetNamespace()+"/"+object.GetName())
+			} else {
+				objectNames = append(objectNames, object.GetName())
+			}
+		}
+		return mcp.NewToolResultStructuredOnly(objectNames), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// redactResource applies the redaction of the typed tools to object: core Secrets are redacted like the secret
+// tool, except for the keys in reveal, and workloads get the sensitive env values in their pod spec redacted.
+// Any other object only has its last-applied-configuration annotation redacted.
+func redactResource(object *unstructured.Unstructured, reveal []string, allowReveal bool) (any, error) {
+	groupVersionKind := object.GroupVersionKind()
+	if groupVersionKind.Group == "" && groupVersionKind.Kind == secretKind {
+		var secret corev1.Secret
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, &secret); err != nil {
+			return nil, err
+		}
+		// Reveal was checked before any write, so missing keys are reported as warnings instead of failing.
+		present, warnings := revealableKeys(&secret, reveal)
+		redactedSecret, err := redactSecret(&secret, present, allowReveal)
+		if err != nil {
+			return nil, err
+		}
+		redactedSecret.Warnings = warnings
+		return redactedSecret, nil
+	}
+	if typed, err := scheme.Scheme.New(groupVersionKind); err == nil {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, typed); err != nil {
+			return nil, err
+		}
+		switch typed := typed.(type) {
+		case *corev1.Pod:
+			redactPodSpec(&typed.ObjectMeta, &typed.Spec)
+			return typed, nil
+		case *corev1.PodTemplate:
+			redactPodSpec(&typed.ObjectMeta, &typed.Template.Spec)
+			return typed, nil
+		case *corev1.ReplicationController:
+			if typed.Spec.Template != nil {
+				redactPodSpec(&typed.ObjectMeta, &typed.Spec.Template.Spec)
+				return typed, nil
+			}
+		case *appsv1.Deployment:
+			redactPodSpec(&typed.ObjectMeta, &typed.Spec.Template.Spec)
+			return typed, nil
+		case *appsv1.ReplicaSet:
+			redactPodSpec(&typed.ObjectMeta, &typed.Spec.Template.Spec)
+			return typed, nil
+		case *appsv1.StatefulSet:
+			redactPodSpec(&typed.ObjectMeta, &typed.Spec.Template.Spec)
+			return typed, nil
+		case *appsv1.DaemonSet:
+			redactPodSpec(&typed.ObjectMeta, &typed.Spec.Template.Spec)
+			return typed, nil
+		case *batchv1.Job:
+			redactPodSpec(&typed.ObjectMeta, &typed.Spec.Template.Spec)
+			return typed, nil
+		case *batchv1.CronJob:
+			redactPodSpec(&typed.ObjectMeta, &typed.Spec.JobTemplate.Spec.Template.Spec)
+			return typed, nil
+		}
+	}
+
+	annotations := object.GetAnnotations()
+	if _, ok := annotations[lastAppliedAnnotation]; ok {
+		annotations[lastAppliedAnnotation] = redacted
+		object.SetAnnotations(annotations)
+	}
+	return object, nil
+}
+
+// resolveResourceType maps resourceType to its REST mapping. When the type is not known, the discovery cache
+// behind restMapper is reset and the lookup retried once, so resources installed after startup are found.
+func resolveResourceType(restMapper meta.RESTMapper, resourceType resourceType) (*meta.RESTMapping, error) {
+	if resourceType.Kind == "" && resourceType.Resource == "" {
+		return nil, errors.New("kind or resource is required")
+	}
+	mapping, err := restMappingFor(restMapper, resourceType)
+	if meta.IsNoMatchError(err) {
+		meta.MaybeResetRESTMapper(restMapper)
+		mapping, err = restMappingFor(restMapper, resourceType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve %s: %w", resourceType, err)
+	}
+	return mapping, nil
+}
+
+func restMappingFor(restMapper meta.RESTMapper, resourceType resourceType) (*meta.RESTMapping, error) {
+	groupVersion, err := schema.ParseGroupVersion(resourceType.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	if resourceType.Kind != "" {
+		if groupVersion.Version == "" {
+			return restMapper.RESTMapping(schema.GroupKind{Group: groupVersion.Group, Kind: resourceType.Kind})
+		}
+		return restMapper.RESTMapping(schema.GroupKind{Group: groupVersion.Group, Kind: resourceType.Kind}, groupVersion.Version)
+	}
+
+	// Resolve the resource like kubectl: as resource.version.group first, then as resource.group.
+	var groupVersionKind schema.GroupVersionKind
+	if resourceType.APIVersion != "" {
+		groupVersionKind, err = restMapper.KindFor(groupVersion.WithResource(strings.ToLower(resourceType.Resource)))
+	} else {
+		fullySpecified, groupResource := schema.ParseResourceArg(strings.ToLower(resourceType.Resource))
+		if fullySpecified != nil {
+			groupVersionKind, err = restMapper.KindFor(*fullySpecified)
+		}
+		if groupVersionKind.Empty() {
+			groupVersionKind, err = restMapper.KindFor(groupResource.WithVersion(""))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return restMapper.RESTMapping(groupVersionKind.GroupKind(), groupVersionKind.Version)
+}
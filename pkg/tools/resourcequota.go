@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+func resourcequotaMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, resourceQuotaInterface v1.ResourceQuotaInterface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		resourceQuotaJson := []byte(resourceSpec)
+		var resourceQuotaSpec corev1.ResourceQuota
+		if err := json.Unmarshal(resourceQuotaJson, &resourceQuotaSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		resourceQuota, err := resourceQuotaInterface.Create(
+			ctx,
+			&corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      resourceQuotaSpec.Labels,
+					Annotations: resourceQuotaSpec.Annotations,
+				},
+				Spec: resourceQuotaSpec.Spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdResourceQuotaSpec, err := json.Marshal(resourceQuota)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created resourcequota: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdResourceQuotaSpec), nil
+	case "delete":
+		err := resourceQuotaInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted resourcequota " + name + " in namespace " + namespace), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var resourceQuotaSpec corev1.ResourceQuota
+		if err := json.Unmarshal([]byte(resourceSpec), &resourceQuotaSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		resourceQuota, err := resourceQuotaInterface.Update(
+			ctx,
+			&corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      resourceQuotaSpec.Labels,
+					Annotations: resourceQuotaSpec.Annotations,
+				},
+				Spec: resourceQuotaSpec.Spec,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedResourceQuotaSpec, err := json.Marshal(resourceQuota)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated resourcequota: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedResourceQuotaSpec), nil
+	case "get":
+		resourceQuota, err := resourceQuotaInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		resourceQuotaSpec, err := json.Marshal(resourceQuota)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal resourcequota: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(resourceQuotaSpec), nil
+	case "list":
+		resourceQuotas, err := resourceQuotaInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var resourceQuotaNames []string
+		for _, resourceQuota := range resourceQuotas.Items {
+			resourceQuotaNames = append(resourceQuotaNames, resourceQuota.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(resourceQuotaNames), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// restartedAtAnnotation is the pod template annotation kubectl rollout restart sets.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+const defaultRolloutTimeout = 300 * time.Second
+
+// restartResult reports the rollout of a single consumer restarted after a configuration change.
+type restartResult struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// restartConsumers triggers a rolling restart of every workload consuming the configmap or secret
+// called name. Workloads are restarted one at a time, waiting for each rollout to finish before
+// moving on to the next one.
+func restartConsumers(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, kind string, name string, timeout time.Duration) ([]restartResult, error) {
+	consumers, err := findConsumers(ctx, kubernetesClient, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = defaultRolloutTimeout
+	}
+
+	results := []restartResult{}
+	for _, consumer := range consumers {
+		result := restartResult{Kind: consumer.Kind, Name: consumer.Name}
+		switch consumer.Kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+			if err := rolloutRestart(ctx, kubernetesClient, namespace, consumer.Kind, consumer.Name); err != nil {
+				result.Status = "failed"
+				result.Message = err.Error()
+				break
+			}
+			if err := waitForRollout(ctx, kubernetesClient, namespace, consumer.Kind, consumer.Name, timeout); err != nil {
+				result.Status = "not ready"
+				result.Message = err.Error()
+				break
+			}
+			result.Status = "rolled out"
+		case "CronJob":
+			result.Status = "skipped"
+			result.Message = "jobs created from the next schedule pick up the change"
+		default:
+			result.Status = "skipped"
+			result.Message = consumer.Kind + " cannot be restarted in place and must be recreated to pick up the change"
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// rolloutRestart sets the restartedAt annotation on the pod template, like kubectl rollout restart.
+func rolloutRestart(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, kind string, name string) error {
+	patch, err := json.Marshal(nestPatch(map[string]any{
+		"annotations": map[string]string{restartedAtAnnotation: time.Now().Format(time.RFC3339)},
+	}, "spec", "template", "metadata"))
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	switch kind {
+	case "Deployment":
+		_, err = kubernetesClient.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = kubernetesClient.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = kubernetesClient.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("cannot restart %s", kind)
+	}
+	return err
+}
+
+// waitForRollout polls the workload until its rollout is complete, using the same checks as kubectl rollout status.
+func waitForRollout(ctx context.Context, kubernetesClient kubernetes.Interface, namespace string, kind string, name string, timeout time.Duration) error {
+	var message string
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		var done bool
+		var err error
+		switch kind {
+		case "Deployment":
+			var deployment *appsv1.Deployment
+			if deployment, err = kubernetesClient.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+				done, message = deploymentRolloutStatus(deployment)
+			}
+		case "StatefulSet":
+			var statefulset *appsv1.StatefulSet
+			if statefulset, err = kubernetesClient.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+				done, message = statefulsetRolloutStatus(statefulset)
+			}
+		case "DaemonSet":
+			var daemonset *appsv1.DaemonSet
+			if daemonset, err = kubernetesClient.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+				done, message = daemonsetRolloutStatus(daemonset)
+			}
+		default:
+			return false, fmt.Errorf("cannot wait for %s", kind)
+		}
+		return done, err
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out after %s: %s", timeout, message)
+	}
+	return err
+}
+
+func deploymentRolloutStatus(deployment *appsv1.Deployment) (bool, string) {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	switch {
+	case status.ObservedGeneration < deployment.Generation:
+		return false, "waiting for the rollout to be observed"
+	case status.UpdatedReplicas < replicas:
+		return false, fmt.Sprintf("%d of %d replicas updated", status.UpdatedReplicas, replicas)
+	case status.Replicas > status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d old replicas pending termination", status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d of %d updated replicas available", status.AvailableReplicas, status.UpdatedReplicas)
+	}
+	return true, "rollout complete"
+}
+
+func statefulsetRolloutStatus(statefulset *appsv1.StatefulSet) (bool, string) {
+	replicas := int32(1)
+	if statefulset.Spec.Replicas != nil {
+		replicas = *statefulset.Spec.Replicas
+	}
+	status := statefulset.Status
+	switch {
+	case statefulset.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType:
+		return true, "update strategy is OnDelete, pods are only replaced when deleted"
+	case status.ObservedGeneration < statefulset.Generation:
+		return false, "waiting for the rollout to be observed"
+	case status.ReadyReplicas < replicas:
+		return false, fmt.Sprintf("%d of %d replicas ready", status.ReadyReplicas, replicas)
+	}
+	if rollingUpdate := statefulset.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil && *rollingUpdate.Partition > 0 {
+		if status.UpdatedReplicas < replicas-*rollingUpdate.Partition {
+			return false, fmt.Sprintf("%d of %d partitioned replicas updated", status.UpdatedReplicas, replicas-*rollingUpdate.Partition)
+		}
+		return true, "partitioned rollout complete"
+	}
+	if status.UpdateRevision != status.CurrentRevision {
+		return false, fmt.Sprintf("%d of %d replicas updated", status.UpdatedReplicas, replicas)
+	}
+	return true, "rollout complete"
+}
+
+func daemonsetRolloutStatus(daemonset *appsv1.DaemonSet) (bool, string) {
+	status := daemonset.Status
+	switch {
+	case daemonset.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType:
+		return true, "update strategy is OnDelete, pods are only replaced when deleted"
+	case status.ObservedGeneration < daemonset.Generation:
+		return false, "waiting for the rollout to be observed"
+	case status.UpdatedNumberScheduled < status.DesiredNumberScheduled:
+		return false, fmt.Sprintf("%d of %d pods updated", status.UpdatedNumberScheduled, status.DesiredNumberScheduled)
+	case status.NumberAvailable < status.DesiredNumberScheduled:
+		return false, fmt.Sprintf("%d of %d updated pods available", status.NumberAvailable, status.DesiredNumberScheduled)
+	}
+	return true, "rollout complete"
+}
@@ -3,14 +3,19 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/ptr"
 )
 
-func secretMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, secretInterface v1.SecretInterface) (*mcp.CallToolResult, error) {
+func secretMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, secretType string, reveal []string, allowReveal bool, restart bool, timeout int, secretInterface v1.SecretInterface, kubernetesClient kubernetes.Interface) (*mcp.CallToolResult, error) {
 
 	switch action {
 	case "create":
@@ -21,9 +26,26 @@ func secretMCPResponse(ctx context.Context, name string, namespace string, actio
 
 		secretJson := []byte(resourceSpec)
 		var secretSpec corev1.Secret
-		if err := json.Unmarshal(secretJson, &secretSpec); err != nil {
+		if secretType != "" {
+			typedSecret, err := buildTypedSecret(secretType, resourceSpec)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			secretSpec = *typedSecret
+		} else if err := json.Unmarshal(secretJson, &secretSpec); err != nil {
 			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
 		}
+		// Check reveal before creating the secret, so a refused reveal leaves nothing behind.
+		if len(reveal) > 0 && !allowReveal {
+			return mcp.NewToolResultError("revealing secret values is disabled by server configuration"), nil
+		}
+		for _, key := range reveal {
+			_, inData := secretSpec.Data[key]
+			_, inStringData := secretSpec.StringData[key]
+			if !inData && !inStringData {
+				return mcp.NewToolResultError(fmt.Sprintf("key %q not found in secret %s", key, name)), nil
+			}
+		}
 
 		secret, err := secretInterface.Create(
 			ctx,
@@ -42,7 +64,11 @@ func secretMCPResponse(ctx context.Context, name string, namespace string, actio
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		createdSecretSpec, err := json.Marshal(secret)
+		redactedSecret, err := redactSecret(secret, reveal, allowReveal)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		createdSecretSpec, err := json.Marshal(redactedSecret)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal created secret: " + err.Error()), nil
 		}
@@ -58,25 +84,85 @@ func secretMCPResponse(ctx context.Context, name string, namespace string, actio
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 		return mcp.NewToolResultText("Deleted secret " + name + " in namespace " + namespace), nil
-	case "update":
-		secret, err := secretInterface.Update(
-			ctx,
-			&corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      name,
-					Namespace: namespace,
+	case "update", "patch", "apply":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for " + action + " action"), nil
+		}
+
+		// Check reveal before writing, so a refused reveal cannot hide a change or skip the restarts.
+		if len(reveal) > 0 && !allowReveal {
+			return mcp.NewToolResultError("revealing secret values is disabled by server configuration"), nil
+		}
+
+		var secret *corev1.Secret
+		var err error
+		switch action {
+		case "update":
+			var secretSpec corev1.Secret
+			if err := json.Unmarshal([]byte(resourceSpec), &secretSpec); err != nil {
+				return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+			}
+			secret, err = secretInterface.Update(
+				ctx,
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        name,
+						Namespace:   namespace,
+						Labels:      secretSpec.Labels,
+						Annotations: secretSpec.Annotations,
+					},
+					Type:       secretSpec.Type,
+					Data:       secretSpec.Data,
+					StringData: secretSpec.StringData,
 				},
-			},
-			metav1.UpdateOptions{},
-		)
+				metav1.UpdateOptions{},
+			)
+		case "patch":
+			secret, err = secretInterface.Patch(
+				ctx,
+				name,
+				types.MergePatchType,
+				[]byte(resourceSpec),
+				metav1.PatchOptions{},
+			)
+		case "apply":
+			var patch []byte
+			if patch, err = applyPatch(resourceSpec, "v1", secretKind, name, namespace); err == nil {
+				secret, err = secretInterface.Patch(
+					ctx,
+					name,
+					types.ApplyPatchType,
+					patch,
+					metav1.PatchOptions{FieldManager: fieldManager, Force: ptr.To(true)},
+				)
+			}
+		}
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		updatedSecretSpec, err := json.Marshal(secret)
+		// The change is done, so keys missing after it are reported as warnings rather than failing the action.
+		present, warnings := revealableKeys(secret, reveal)
+		redactedSecret, err := redactSecret(secret, present, allowReveal)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactedSecret.Warnings = warnings
+		updatedSecretSpec, err := json.Marshal(redactedSecret)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal updated secret: " + err.Error()), nil
 		}
-		return mcp.NewToolResultStructuredOnly(updatedSecretSpec), nil
+		if !restart {
+			return mcp.NewToolResultStructuredOnly(updatedSecretSpec), nil
+		}
+
+		restarts, err := restartConsumers(ctx, kubernetesClient, namespace, secretKind, name, time.Duration(timeout)*time.Second)
+		if err != nil {
+			return mcp.NewToolResultError("Updated secret but failed to restart consumers: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(configUpdateResult{
+			Object:   updatedSecretSpec,
+			Restarts: restarts,
+		}), nil
 	case "get":
 		secret, err := secretInterface.Get(
 			ctx,
@@ -86,7 +172,11 @@ func secretMCPResponse(ctx context.Context, name string, namespace string, actio
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		secretSpec, err := json.Marshal(secret)
+		redactedSecret, err := redactSecret(secret, reveal, allowReveal)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		secretSpec, err := json.Marshal(redactedSecret)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal secret: " + err.Error()), nil
 		}
@@ -104,6 +194,25 @@ func secretMCPResponse(ctx context.Context, name string, namespace string, actio
 			secretNames = append(secretNames, secret.Name)
 		}
 		return mcp.NewToolResultStructuredOnly(secretNames), nil
+	case "consumers":
+		consumers, err := findConsumers(ctx, kubernetesClient, namespace, secretKind, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(consumers), nil
 	}
 	return mcp.NewToolResultError("Unknown action: " + action), nil
 }
+
+// revealableKeys splits reveal into the keys present in secret and a warning for each key that is missing.
+func revealableKeys(secret *corev1.Secret, reveal []string) ([]string, []string) {
+	var present, warnings []string
+	for _, key := range reveal {
+		if _, ok := secret.Data[key]; ok {
+			present = append(present, key)
+		} else {
+			warnings = append(warnings, fmt.Sprintf("key %q not found in secret %s, its value was not revealed", key, secret.Name))
+		}
+	}
+	return present, warnings
+}
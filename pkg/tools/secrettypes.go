@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	dockerRegistrySecret = "docker-registry"
+	tlsSecret            = "tls"
+	basicAuthSecret      = "basic-auth"
+	sshAuthSecret        = "ssh-auth"
+	genericSecret        = "generic"
+
+	defaultDockerRegistry = "https://index.docker.io/v1/"
+)
+
+var secretTypes = []string{dockerRegistrySecret, tlsSecret, basicAuthSecret, sshAuthSecret, genericSecret}
+
+// secretInput holds the plain inputs of the typed secret creation modes.
+type secretInput struct {
+	Server     string   `json:"server"`
+	Username   string   `json:"username"`
+	Password   string   `json:"password"`
+	Email      string   `json:"email"`
+	Cert       string   `json:"cert"`
+	Key        string   `json:"key"`
+	PrivateKey string   `json:"privateKey"`
+	Literals   []string `json:"literals"`
+}
+
+// buildTypedSecret builds a correctly typed secret of secretType from the plain inputs in resourceSpec.
+func buildTypedSecret(secretType string, resourceSpec string) (*corev1.Secret, error) {
+	var input secretInput
+	if err := json.Unmarshal([]byte(resourceSpec), &input); err != nil {
+		return nil, fmt.Errorf("invalid resourceSpec JSON: %w", err)
+	}
+
+	switch secretType {
+	case dockerRegistrySecret:
+		if input.Username == "" || input.Password == "" {
+			return nil, fmt.Errorf("username and password are required for %s secrets", secretType)
+		}
+		if input.Server == "" {
+			input.Server = defaultDockerRegistry
+		}
+		dockerConfig, err := json.Marshal(map[string]any{
+			"auths": map[string]any{
+				input.Server: map[string]string{
+					"username": input.Username,
+					"password": input.Password,
+					"email":    input.Email,
+					"auth":     base64.StdEncoding.EncodeToString([]byte(input.Username + ":" + input.Password)),
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal docker config: %w", err)
+		}
+		return &corev1.Secret{
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: dockerConfig},
+		}, nil
+	case tlsSecret:
+		if input.Cert == "" || input.Key == "" {
+			return nil, fmt.Errorf("cert and key are required for %s secrets", secretType)
+		}
+		if _, err := tls.X509KeyPair([]byte(input.Cert), []byte(input.Key)); err != nil {
+			return nil, fmt.Errorf("invalid certificate and key pair: %w", err)
+		}
+		return &corev1.Secret{
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte(input.Cert),
+				corev1.TLSPrivateKeyKey: []byte(input.Key),
+			},
+		}, nil
+	case basicAuthSecret:
+		if input.Username == "" && input.Password == "" {
+			return nil, fmt.Errorf("username or password is required for %s secrets", secretType)
+		}
+		data := map[string][]byte{}
+		if input.Username != "" {
+			data[corev1.BasicAuthUsernameKey] = []byte(input.Username)
+		}
+		if input.Password != "" {
+			data[corev1.BasicAuthPasswordKey] = []byte(input.Password)
+		}
+		return &corev1.Secret{
+			Type: corev1.SecretTypeBasicAuth,
+			Data: data,
+		}, nil
+	case sshAuthSecret:
+		if block, _ := pem.Decode([]byte(input.PrivateKey)); block == nil {
+			return nil, fmt.Errorf("privateKey must be a PEM encoded private key")
+		}
+		return &corev1.Secret{
+			Type: corev1.SecretTypeSSHAuth,
+			Data: map[string][]byte{corev1.SSHAuthPrivateKey: []byte(input.PrivateKey)},
+		}, nil
+	case genericSecret:
+		data, err := parseLiterals(input.Literals)
+		if err != nil {
+			return nil, err
+		}
+		byteData := make(map[string][]byte, len(data))
+		for key, value := range data {
+			byteData[key] = []byte(value)
+		}
+		return &corev1.Secret{
+			Type: corev1.SecretTypeOpaque,
+			Data: byteData,
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown secretType %q, expected one of %s", secretType, strings.Join(secretTypes, ", "))
+}
+
+// parseLiterals turns key=value literals into a map, rejecting invalid and duplicate keys.
+func parseLiterals(literals []string) (map[string]string, error) {
+	if len(literals) == 0 {
+		return nil, fmt.Errorf("at least one key=value literal is required")
+	}
+	data := make(map[string]string, len(literals))
+	for _, literal := range literals {
+		key, value, found := strings.Cut(literal, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid literal %q, expected key=value", literal)
+		}
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if _, ok := data[key]; ok {
+			return nil, fmt.Errorf("duplicate key %q", key)
+		}
+		data[key] = value
+	}
+	return data, nil
+}
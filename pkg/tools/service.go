@@ -3,14 +3,38 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sort"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	discoveryv1client "k8s.io/client-go/kubernetes/typed/discovery/v1"
 )
 
-func serviceMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, serviceInterface v1.ServiceInterface) (*mcp.CallToolResult, error) {
+// serviceEndpoints lists the addresses backing a service, as found in its EndpointSlices.
+type serviceEndpoints struct {
+	Service   string            `json:"service"`
+	Selector  map[string]string `json:"selector,omitempty"`
+	Endpoints []serviceEndpoint `json:"endpoints"`
+	Warnings  []string          `json:"warnings,omitempty"`
+}
+
+type serviceEndpoint struct {
+	Address     string   `json:"address"`
+	Pod         string   `json:"pod,omitempty"`
+	Node        string   `json:"node,omitempty"`
+	Zone        string   `json:"zone,omitempty"`
+	Ready       bool     `json:"ready"`
+	Serving     bool     `json:"serving"`
+	Terminating bool     `json:"terminating"`
+	Ports       []string `json:"ports"`
+}
+
+func serviceMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, serviceInterface v1.ServiceInterface, endpointSliceInterface discoveryv1client.EndpointSliceInterface, podInterface v1.PodInterface) (*mcp.CallToolResult, error) {
 
 	switch action {
 	case "create":
@@ -102,6 +126,123 @@ func serviceMCPResponse(ctx context.Context, name string, namespace string, acti
 			serviceNames = append(serviceNames, service.Name)
 		}
 		return mcp.NewToolResultStructuredOnly(serviceNames), nil
+	case "endpoints":
+		service, err := serviceInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		endpoints, err := resolveServiceEndpoints(ctx, service, endpointSliceInterface, podInterface)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(endpoints), nil
 	}
 	return mcp.NewToolResultError("Unknown action: " + action), nil
 }
+
+// resolveServiceEndpoints reads the EndpointSlices of service and flags selectors that match no pods or no ready pods.
+func resolveServiceEndpoints(ctx context.Context, service *corev1.Service, endpointSliceInterface discoveryv1client.EndpointSliceInterface, podInterface v1.PodInterface) (*serviceEndpoints, error) {
+	result := &serviceEndpoints{
+		Service:   service.Name,
+		Selector:  service.Spec.Selector,
+		Endpoints: []serviceEndpoint{},
+	}
+
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		result.Warnings = append(result.Warnings, "Service is of type ExternalName and resolves to "+service.Spec.ExternalName+" without endpoints")
+		return result, nil
+	}
+
+	slices, err := endpointSliceInterface.List(
+		ctx,
+		metav1.ListOptions{LabelSelector: discoveryv1.LabelServiceName + "=" + service.Name},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, slice := range slices.Items {
+		var ports []string
+		for _, servicePort := range service.Spec.Ports {
+			for _, slicePort := range slice.Ports {
+				if slicePort.Name == nil || *slicePort.Name != servicePort.Name || slicePort.Port == nil {
+					continue
+				}
+				protocol := corev1.ProtocolTCP
+				if slicePort.Protocol != nil {
+					protocol = *slicePort.Protocol
+				}
+				ports = append(ports, fmt.Sprintf("%d->%d/%s", servicePort.Port, *slicePort.Port, protocol))
+			}
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			for _, address := range endpoint.Addresses {
+				resolved := serviceEndpoint{
+					Address: address,
+					// A nil ready condition means ready, and serving defaults to ready.
+					Ready:       endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready,
+					Terminating: endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating,
+					Ports:       ports,
+				}
+				resolved.Serving = resolved.Ready
+				if endpoint.Conditions.Serving != nil {
+					resolved.Serving = *endpoint.Conditions.Serving
+				}
+				if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+					resolved.Pod = endpoint.TargetRef.Name
+				}
+				if endpoint.NodeName != nil {
+					resolved.Node = *endpoint.NodeName
+				}
+				if endpoint.Zone != nil {
+					resolved.Zone = *endpoint.Zone
+				}
+				result.Endpoints = append(result.Endpoints, resolved)
+			}
+		}
+	}
+	sort.Slice(result.Endpoints, func(i, j int) bool {
+		return result.Endpoints[i].Address < result.Endpoints[j].Address
+	})
+
+	if len(service.Spec.Selector) == 0 {
+		result.Warnings = append(result.Warnings, "Service has no selector, so its endpoints are managed manually")
+		return result, nil
+	}
+
+	pods, err := podInterface.List(
+		ctx,
+		metav1.ListOptions{LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	ready := 0
+	for _, pod := range pods.Items {
+		if podReady(&pod) {
+			ready++
+		}
+	}
+	switch {
+	case len(pods.Items) == 0:
+		result.Warnings = append(result.Warnings, "No pods match the service selector "+labels.SelectorFromSet(service.Spec.Selector).String())
+	case ready == 0:
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%d pods match the service selector but none are ready", len(pods.Items)))
+	}
+	return result, nil
+}
+
+// podReady reports whether the pod has a true Ready condition.
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
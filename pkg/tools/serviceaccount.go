@@ -0,0 +1,229 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	kubernetes_client "github.com/TheisFerre/kubernetes-mcp-server/pkg/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
+)
+
+// defaultTokenExpiration is the lifetime requested for service account tokens when none is given.
+const defaultTokenExpiration = time.Hour
+
+// tokenOptions are the parameters of a TokenRequest.
+type tokenOptions struct {
+	Audiences  []string
+	Expiration time.Duration
+}
+
+// serviceAccountToken is a token issued for a service account by the TokenRequest API.
+type serviceAccountToken struct {
+	ServiceAccount      string    `json:"serviceAccount"`
+	Namespace           string    `json:"namespace"`
+	Token               string    `json:"token"`
+	Audiences           []string  `json:"audiences"`
+	ExpirationTimestamp time.Time `json:"expirationTimestamp"`
+}
+
+func serviceaccountMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, token tokenOptions, allowReveal bool, serviceAccountInterface v1.ServiceAccountInterface, kubernetesConfig *rest.Config) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		serviceAccountJson := []byte(resourceSpec)
+		var serviceAccountSpec corev1.ServiceAccount
+		if err := json.Unmarshal(serviceAccountJson, &serviceAccountSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+
+		serviceAccount, err := serviceAccountInterface.Create(
+			ctx,
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      serviceAccountSpec.Labels,
+					Annotations: serviceAccountSpec.Annotations,
+				},
+				Secrets:                      serviceAccountSpec.Secrets,
+				ImagePullSecrets:             serviceAccountSpec.ImagePullSecrets,
+				AutomountServiceAccountToken: serviceAccountSpec.AutomountServiceAccountToken,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdServiceAccountSpec, err := json.Marshal(serviceAccount)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created serviceaccount: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdServiceAccountSpec), nil
+	case "delete":
+		err := serviceAccountInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted serviceaccount " + name + " in namespace " + namespace), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		var serviceAccountSpec corev1.ServiceAccount
+		if err := json.Unmarshal([]byte(resourceSpec), &serviceAccountSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		serviceAccount, err := serviceAccountInterface.Update(
+			ctx,
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   namespace,
+					Labels:      serviceAccountSpec.Labels,
+					Annotations: serviceAccountSpec.Annotations,
+				},
+				Secrets:                      serviceAccountSpec.Secrets,
+				ImagePullSecrets:             serviceAccountSpec.ImagePullSecrets,
+				AutomountServiceAccountToken: serviceAccountSpec.AutomountServiceAccountToken,
+			},
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedServiceAccountSpec, err := json.Marshal(serviceAccount)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated serviceaccount: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedServiceAccountSpec), nil
+	case "get":
+		serviceAccount, err := serviceAccountInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		serviceAccountSpec, err := json.Marshal(serviceAccount)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal serviceaccount: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(serviceAccountSpec), nil
+	case "list":
+		serviceAccounts, err := serviceAccountInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var serviceAccountNames []string
+		for _, serviceAccount := range serviceAccounts.Items {
+			serviceAccountNames = append(serviceAccountNames, serviceAccount.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(serviceAccountNames), nil
+	case "token":
+		if !allowReveal {
+			return mcp.NewToolResultError("issuing service account tokens is disabled by server configuration"), nil
+		}
+		issued, err := requestServiceAccountToken(ctx, serviceAccountInterface, name, namespace, token)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(issued), nil
+	case "kubeconfig":
+		if !allowReveal {
+			return mcp.NewToolResultError("issuing service account tokens is disabled by server configuration"), nil
+		}
+		// The token must be accepted by the API server, so the default audience is always used.
+		issued, err := requestServiceAccountToken(ctx, serviceAccountInterface, name, namespace, tokenOptions{Expiration: token.Expiration})
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		kubeconfig, err := serviceAccountKubeconfig(issued, kubernetesConfig)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(string(kubeconfig)), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
+
+// requestServiceAccountToken issues a bound token for the service account through the TokenRequest API.
+// The API server may shorten the requested expiration.
+func requestServiceAccountToken(ctx context.Context, serviceAccountInterface v1.ServiceAccountInterface, name string, namespace string, options tokenOptions) (*serviceAccountToken, error) {
+	expiration := options.Expiration
+	if expiration <= 0 {
+		expiration = defaultTokenExpiration
+	}
+	tokenRequest, err := serviceAccountInterface.CreateToken(
+		ctx,
+		name,
+		&authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				Audiences:         options.Audiences,
+				ExpirationSeconds: ptr.To(int64(expiration.Seconds())),
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &serviceAccountToken{
+		ServiceAccount:      name,
+		Namespace:           namespace,
+		Token:               tokenRequest.Status.Token,
+		Audiences:           tokenRequest.Spec.Audiences,
+		ExpirationTimestamp: tokenRequest.Status.ExpirationTimestamp.Time,
+	}, nil
+}
+
+// serviceAccountKubeconfig renders a kubeconfig that authenticates as the service account with the issued token,
+// against the server and CA of the client configuration this server uses.
+func serviceAccountKubeconfig(token *serviceAccountToken, kubernetesConfig *rest.Config) ([]byte, error) {
+	caData, err := kubernetes_client.CertificateAuthorityData(kubernetesConfig)
+	if err != nil {
+		return nil, err
+	}
+	clusterName := "cluster"
+	userName := token.ServiceAccount + "@" + token.Namespace
+	contextName := userName
+
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[clusterName] = &clientcmdapi.Cluster{
+		Server:                   kubernetesConfig.Host,
+		CertificateAuthorityData: caData,
+		InsecureSkipTLSVerify:    kubernetesConfig.Insecure,
+		TLSServerName:            kubernetesConfig.ServerName,
+	}
+	kubeconfig.AuthInfos[userName] = &clientcmdapi.AuthInfo{Token: token.Token}
+	kubeconfig.Contexts[contextName] = &clientcmdapi.Context{
+		Cluster:   clusterName,
+		AuthInfo:  userName,
+		Namespace: token.Namespace,
+	}
+	kubeconfig.CurrentContext = contextName
+	return clientcmd.Write(*kubeconfig)
+}
@@ -3,14 +3,36 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
-func statefulsetMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, statefulsetInterface v1.StatefulSetInterface) (*mcp.CallToolResult, error) {
+// statefulsetClaim is a persistentvolumeclaim created from one of a statefulset's volumeClaimTemplates.
+type statefulsetClaim struct {
+	Name         string                            `json:"name"`
+	Template     string                            `json:"template"`
+	Ordinal      int                               `json:"ordinal"`
+	Phase        corev1.PersistentVolumeClaimPhase `json:"phase"`
+	Volume       string                            `json:"volume,omitempty"`
+	StorageClass string                            `json:"storageClass,omitempty"`
+	Capacity     string                            `json:"capacity,omitempty"`
+	Pod          string                            `json:"pod,omitempty"`
+	// Orphaned claims belong to ordinals beyond the current replica count; they are kept after a scale down.
+	Orphaned bool `json:"orphaned,omitempty"`
+}
+
+func statefulsetMCPResponse(ctx context.Context, name string, namespace string, action string, resourceSpec string, images []string, statefulsetInterface v1.StatefulSetInterface, pvcInterface corev1client.PersistentVolumeClaimInterface, podInterface corev1client.PodInterface) (*mcp.CallToolResult, error) {
 
 	switch action {
 	case "create":
@@ -40,6 +62,7 @@ func statefulsetMCPResponse(ctx context.Context, name string, namespace string,
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		redactPodSpec(&statefulset.ObjectMeta, &statefulset.Spec.Template.Spec)
 		createdStatefulSetSpec, err := json.Marshal(statefulset)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal created statefulset: " + err.Error()), nil
@@ -70,6 +93,7 @@ func statefulsetMCPResponse(ctx context.Context, name string, namespace string,
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&statefulset.ObjectMeta, &statefulset.Spec.Template.Spec)
 		updatedStatefulSetSpec, err := json.Marshal(statefulset)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal updated statefulset: " + err.Error()), nil
@@ -84,6 +108,7 @@ func statefulsetMCPResponse(ctx context.Context, name string, namespace string,
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		redactPodSpec(&statefulset.ObjectMeta, &statefulset.Spec.Template.Spec)
 		statefulsetSpec, err := json.Marshal(statefulset)
 		if err != nil {
 			return mcp.NewToolResultError("Failed to marshal statefulset: " + err.Error()), nil
@@ -102,6 +127,149 @@ func statefulsetMCPResponse(ctx context.Context, name string, namespace string,
 			statefulsetNames = append(statefulsetNames, statefulset.Name)
 		}
 		return mcp.NewToolResultStructuredOnly(statefulsetNames), nil
+	case "set_image":
+		statefulset, err := statefulsetInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, changes, err := setImagePatch(&statefulset.Spec.Template.Spec, images, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		_, err = statefulsetInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(changes), nil
+	case "edit_template":
+		statefulset, err := statefulsetInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		patch, container, err := editTemplatePatch(&statefulset.Spec.Template.Spec, resourceSpec, "spec", "template", "spec")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		statefulset, err = statefulsetInterface.Patch(
+			ctx,
+			name,
+			types.StrategicMergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		redactPodSpec(&statefulset.ObjectMeta, &statefulset.Spec.Template.Spec)
+		return mcp.NewToolResultStructuredOnly(findContainer(&statefulset.Spec.Template.Spec, container)), nil
+	case "claims":
+		statefulset, err := statefulsetInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		claims, err := statefulsetClaims(ctx, statefulset, pvcInterface, podInterface)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(claims), nil
 	}
 	return mcp.NewToolResultError("Unknown action: " + action), nil
 }
+
+// statefulsetClaims lists the claims of every volumeClaimTemplate, named <template>-<statefulset>-<ordinal>, with
+// their binding and the pod using them. Claims left behind by a scale down are included and marked orphaned.
+func statefulsetClaims(ctx context.Context, statefulset *appsv1.StatefulSet, pvcInterface corev1client.PersistentVolumeClaimInterface, podInterface corev1client.PodInterface) ([]statefulsetClaim, error) {
+	claims := []statefulsetClaim{}
+	if len(statefulset.Spec.VolumeClaimTemplates) == 0 {
+		return claims, nil
+	}
+
+	pvcs, err := pvcInterface.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]*corev1.PersistentVolumeClaim{}
+	for i := range pvcs.Items {
+		existing[pvcs.Items[i].Name] = &pvcs.Items[i]
+	}
+
+	replicas := int(replicasOrDefault(statefulset.Spec.Replicas))
+	start := 0
+	if statefulset.Spec.Ordinals != nil {
+		start = int(statefulset.Spec.Ordinals.Start)
+	}
+	for _, template := range statefulset.Spec.VolumeClaimTemplates {
+		prefix := fmt.Sprintf("%s-%s-", template.Name, statefulset.Name)
+		ordinals := map[int]bool{}
+		for ordinal := start; ordinal < start+replicas; ordinal++ {
+			ordinals[ordinal] = true
+		}
+		for pvcName := range existing {
+			if ordinal, err := strconv.Atoi(strings.TrimPrefix(pvcName, prefix)); err == nil && strings.HasPrefix(pvcName, prefix) && ordinal >= 0 {
+				ordinals[ordinal] = true
+			}
+		}
+
+		for _, ordinal := range sortedOrdinals(ordinals) {
+			claim := statefulsetClaim{
+				Name:     fmt.Sprintf("%s%d", prefix, ordinal),
+				Template: template.Name,
+				Ordinal:  ordinal,
+				Phase:    "Missing",
+				Orphaned: ordinal < start || ordinal >= start+replicas,
+			}
+			if pvc, ok := existing[claim.Name]; ok {
+				claim.Phase = pvc.Status.Phase
+				claim.Volume = pvc.Spec.VolumeName
+				if pvc.Spec.StorageClassName != nil {
+					claim.StorageClass = *pvc.Spec.StorageClassName
+				}
+				if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+					claim.Capacity = capacity.String()
+				}
+			}
+
+			podName := fmt.Sprintf("%s-%d", statefulset.Name, ordinal)
+			pod, err := podInterface.Get(ctx, podName, metav1.GetOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			if err == nil {
+				for _, volume := range pod.Spec.Volumes {
+					if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claim.Name {
+						claim.Pod = pod.Name
+					}
+				}
+			}
+			claims = append(claims, claim)
+		}
+	}
+	return claims, nil
+}
+
+func sortedOrdinals(ordinals map[int]bool) []int {
+	var sorted []int
+	for ordinal := range ordinals {
+		sorted = append(sorted, ordinal)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/storage/v1"
+)
+
+// defaultStorageClassAnnotation marks the storage class used by claims that do not name one.
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+func storageclassMCPResponse(ctx context.Context, name string, action string, resourceSpec string, storageClassInterface v1.StorageClassInterface) (*mcp.CallToolResult, error) {
+
+	switch action {
+	case "create":
+
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for create action"), nil
+		}
+
+		storageClassJson := []byte(resourceSpec)
+		var storageClassSpec storagev1.StorageClass
+		if err := json.Unmarshal(storageClassJson, &storageClassSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		storageClassSpec.ObjectMeta = metav1.ObjectMeta{
+			Name:        name,
+			Labels:      storageClassSpec.Labels,
+			Annotations: storageClassSpec.Annotations,
+		}
+
+		storageClass, err := storageClassInterface.Create(
+			ctx,
+			&storageClassSpec,
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		createdStorageClassSpec, err := json.Marshal(storageClass)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal created storageclass: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultStructuredOnly(createdStorageClassSpec), nil
+	case "delete":
+		err := storageClassInterface.Delete(
+			ctx,
+			name,
+			metav1.DeleteOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("Deleted storageclass " + name), nil
+	case "update":
+		if resourceSpec == "" {
+			return mcp.NewToolResultError("resourceSpec is required for update action"), nil
+		}
+		// Storage classes have no spec; everything but the labels, annotations, mount options and
+		// allowVolumeExpansion is immutable, so the whole object is sent as is.
+		var storageClassSpec storagev1.StorageClass
+		if err := json.Unmarshal([]byte(resourceSpec), &storageClassSpec); err != nil {
+			return mcp.NewToolResultError("Invalid resourceSpec JSON: " + err.Error()), nil
+		}
+		storageClassSpec.ObjectMeta = metav1.ObjectMeta{
+			Name:        name,
+			Labels:      storageClassSpec.Labels,
+			Annotations: storageClassSpec.Annotations,
+		}
+		storageClass, err := storageClassInterface.Update(
+			ctx,
+			&storageClassSpec,
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		updatedStorageClassSpec, err := json.Marshal(storageClass)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal updated storageclass: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(updatedStorageClassSpec), nil
+	case "get":
+		storageClass, err := storageClassInterface.Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		storageClassSpec, err := json.Marshal(storageClass)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to marshal storageclass: " + err.Error()), nil
+		}
+		return mcp.NewToolResultStructuredOnly(storageClassSpec), nil
+	case "list":
+		storageClasses, err := storageClassInterface.List(
+			ctx,
+			metav1.ListOptions{},
+		)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var storageClassNames []string
+		for _, storageClass := range storageClasses.Items {
+			storageClassNames = append(storageClassNames, storageClass.Name)
+		}
+		return mcp.NewToolResultStructuredOnly(storageClassNames), nil
+	}
+	return mcp.NewToolResultError("Unknown action: " + action), nil
+}
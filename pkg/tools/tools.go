@@ -2,10 +2,17 @@ package tools
 
 import (
 	"context"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 )
 
 const (
@@ -19,9 +26,93 @@ const (
 	service     = "service"
 	configmap   = "configmap"
 	secret      = "secret"
+	certificate = "certificate"
+	// namespaceResource is not called namespace to avoid clashing with the namespace arguments.
+	namespaceResource = "namespace"
+	node              = "node"
+	// eventsResource is not called events to avoid clashing with event list variables.
+	eventsResource        = "events"
+	persistentvolumeclaim = "persistentvolumeclaim"
+	persistentvolume      = "persistentvolume"
+	storageclass          = "storageclass"
+	ingress               = "ingress"
+	ingressclass          = "ingressclass"
+	networkpolicy         = "networkpolicy"
+	hpa                   = "hpa"
+	rbac                  = "rbac"
+	serviceaccount        = "serviceaccount"
+	resourcequota         = "resourcequota"
+	limitrange            = "limitrange"
+	quotaUsage            = "quota_usage"
+	pdb                   = "pdb"
+	// genericResource is not called resource to avoid clashing with the resource package.
+	genericResource = "resource"
+	// apiResourcesTool is not called apiResources to avoid clashing with the result type of its list action.
+	apiResourcesTool = "api_resources"
 )
 
-func InitializeTools(server *server.MCPServer, kubernetesClient *kubernetes.Clientset) {
+// clusterScoped lists tools for resources that do not live in a namespace, so they take no namespace argument.
+var clusterScoped = map[string]bool{
+	namespaceResource: true,
+	node:              true,
+	persistentvolume:  true,
+	storageclass:      true,
+	ingressclass:      true,
+	apiResourcesTool:  true,
+}
+
+// optionalName lists tools whose actions mostly work on many objects, so the name can be left out.
+var optionalName = map[string]bool{
+	certificate:      true,
+	eventsResource:   true,
+	rbac:             true,
+	quotaUsage:       true,
+	pdb:              true,
+	genericResource:  true,
+	apiResourcesTool: true,
+}
+
+// optionalNamespace lists tools where an empty namespace means all namespaces.
+var optionalNamespace = map[string]bool{
+	certificate:     true,
+	eventsResource:  true,
+	rbac:            true,
+	pdb:             true,
+	genericResource: true,
+}
+
+// toolKinds maps the resource tools to the kind of the objects they manage, for looking up their events and describing them.
+var toolKinds = map[string]string{
+	pod:                   "Pod",
+	deployment:            "Deployment",
+	statefulset:           "StatefulSet",
+	daemonset:             "DaemonSet",
+	replicaset:            "ReplicaSet",
+	job:                   "Job",
+	cronjob:               "CronJob",
+	service:               "Service",
+	configmap:             "ConfigMap",
+	secret:                "Secret",
+	namespaceResource:     "Namespace",
+	node:                  "Node",
+	persistentvolumeclaim: "PersistentVolumeClaim",
+	persistentvolume:      "PersistentVolume",
+	storageclass:          "StorageClass",
+	ingress:               "Ingress",
+	ingressclass:          "IngressClass",
+	networkpolicy:         "NetworkPolicy",
+	hpa:                   "HorizontalPodAutoscaler",
+	serviceaccount:        "ServiceAccount",
+	resourcequota:         "ResourceQuota",
+	limitrange:            "LimitRange",
+	pdb:                   "PodDisruptionBudget",
+}
+
+func InitializeTools(server *server.MCPServer, kubernetesConfig *rest.Config, kubernetesClient *kubernetes.Clientset, dynamicClient dynamic.Interface, allowSecretReveal bool) {
+
+	// Resource types are resolved through a discovery cache kept in memory for the lifetime of the server.
+	cachedDiscovery := memory.NewMemCacheClient(kubernetesClient.Discovery())
+	restMapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscovery), cachedDiscovery, nil)
 
 	for _, tool := range []string{
 		pod,
@@ -34,8 +125,27 @@ func InitializeTools(server *server.MCPServer, kubernetesClient *kubernetes.Clie
 		service,
 		configmap,
 		secret,
+		certificate,
+		namespaceResource,
+		node,
+		eventsResource,
+		persistentvolumeclaim,
+		persistentvolume,
+		storageclass,
+		ingress,
+		ingressclass,
+		networkpolicy,
+		hpa,
+		rbac,
+		serviceaccount,
+		resourcequota,
+		limitrange,
+		quotaUsage,
+		pdb,
+		genericResource,
+		apiResourcesTool,
 	} {
-		addTool(context.Background(), server, registerTool(tool, kubernetesClient), kubernetesClient)
+		addTool(context.Background(), server, registerTool(tool, kubernetesClient), kubernetesConfig, kubernetesClient, dynamicClient, cachedDiscovery, restMapper, allowSecretReveal)
 
 	}
 }
@@ -44,30 +154,298 @@ func registerTool(tool string, kubernetesClient *kubernetes.Clientset) mcp.Tool
 	// Register the tool with the system (this is a placeholder for actual registration logic)
 	// In a real implementation, this could involve adding the tool to a registry or initializing it
 	// For now, we just print the tool name to simulate registration
-	resourceTool := mcp.NewTool(tool,
-		mcp.WithDescription("Tool for managing "+tool+" resources in Kubernetes"),
-		mcp.WithString("name",
-			mcp.Required(),
-			mcp.Description("The name of the "+tool+" resource"),
-		),
-		mcp.WithString("namespace",
-			mcp.Required(),
-			mcp.Description("The namespace where the "+tool+" resource is located"),
-		),
+	description := "Tool for managing " + tool + " resources in Kubernetes"
+	actions := []string{"create", "delete", "update", "get", "list"}
+	resourceSpecDescription := "The specification for the " + tool + " resource in JSON format (optional, used for create/update actions)"
+	var extraOptions []mcp.ToolOption
+	switch tool {
+	case deployment, statefulset, daemonset, replicaset, job, cronjob:
+		actions = append(actions, "set_image", "edit_template")
+		resourceSpecDescription = "The specification for the " + tool + " resource in JSON format (optional, used for create/update actions). " +
+			"For edit_template it is an object with container, env (env vars, valueFrom supported), removeEnv (env var names), resources, readinessProbe and livenessProbe"
+		extraOptions = append(extraOptions,
+			mcp.WithArray("images",
+				mcp.Description("Container image assignments in the form container=image, or *=image for all containers (used for set_image action)"),
+				mcp.WithStringItems(),
+			),
+		)
+	}
+	switch tool {
+	case namespaceResource:
+		description = "Tool for managing namespaces in Kubernetes, including explaining namespaces stuck in Terminating"
+		actions = []string{"create", "delete", "get", "list", "status"}
+		resourceSpecDescription = "The namespace in JSON format (optional, used for create action to set labels and annotations)"
+		extraOptions = append(extraOptions,
+			mcp.WithString("podSecurity",
+				mcp.Description("The Pod Security Admission level to enforce, audit and warn on (used for create action)"),
+				mcp.Enum(podSecurityLevels...),
+			),
+		)
+	case eventsResource:
+		description = "Tool for listing Kubernetes events as a chronological timeline, with repeated events collapsed into one entry with a count"
+		actions = []string{"list"}
+		extraOptions = append(extraOptions,
+			mcp.WithString("kind",
+				mcp.Description("Only return events about objects of this kind, e.g. Pod or Deployment"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Only return events of this type"),
+				mcp.Enum(eventTypes...),
+			),
+			mcp.WithString("reason",
+				mcp.Description("Only return events with this reason, e.g. BackOff or FailedScheduling"),
+			),
+			mcp.WithNumber("sinceMinutes",
+				mcp.Description("Only return events last seen within this many minutes"),
+			),
+			mcp.WithString("api",
+				mcp.Description("The API to read events from (defaults to core)"),
+				mcp.Enum(eventsAPIs...),
+			),
+		)
+	case node:
+		description = "Tool for inspecting and maintaining Kubernetes nodes: conditions, taints, capacity, cordon, uncordon and drain"
+		actions = []string{"get", "list", "cordon", "uncordon", "drain"}
+		extraOptions = append(extraOptions,
+			mcp.WithBoolean("deleteEmptyDirData",
+				mcp.Description("Evict pods using emptyDir volumes, losing their data (used for drain action)"),
+			),
+			mcp.WithBoolean("force",
+				mcp.Description("Evict pods that are not managed by a controller; they will not be recreated (used for drain action)"),
+			),
+			mcp.WithBoolean("dryRun",
+				mcp.Description("Only report which pods would be evicted, skipped or block the drain, without changing anything (used for drain action)"),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description("The maximum time to wait for all evictions in seconds (used for drain action, defaults to 300)"),
+			),
+		)
+	case serviceaccount:
+		actions = append(actions, "token", "kubeconfig")
+		extraOptions = append(extraOptions,
+			mcp.WithArray("audiences",
+				mcp.Description("The audiences of the token, defaults to the API server (used for token action)"),
+				mcp.WithStringItems(),
+			),
+			mcp.WithNumber("expirationSeconds",
+				mcp.Description("The requested lifetime of the token in seconds, defaults to 3600; the API server may shorten it (used for token and kubeconfig actions)"),
+			),
+		)
+	case apiResourcesTool:
+		description = "Tool for discovering the API resources served by the cluster, like kubectl api-resources (list) and kubectl api-versions (versions). " +
+			"The name optionally filters resources by a substring of their name, singular name, kind or short names"
+		actions = []string{"list", "versions"}
+		extraOptions = append(extraOptions,
+			mcp.WithString("group",
+				mcp.Description("Only return resources or versions of this API group, e.g. apps; or core for the core API"),
+			),
+			mcp.WithString("verb",
+				mcp.Description("Only return resources supporting this verb, e.g. list or watch (used for list action)"),
+			),
+			mcp.WithBoolean("refresh",
+				mcp.Description("Discard the cached discovery information first, e.g. after installing a CRD"),
+			),
+		)
+	case genericResource:
+		description = "Tool for managing any Kubernetes resource, including custom resources, through the dynamic client. " +
+			"The type is given by apiVersion and kind, or by a resource name like kubectl accepts (plural, singular or short name, optionally as resource.group). " +
+			"Leave namespace empty for cluster-scoped resources, or to list across all namespaces"
+		actions = []string{"get", "list", "create", "patch", "apply", "delete"}
+		resourceSpecDescription = "The object in JSON format (used for create/apply actions), or the patch (used for patch action)"
+		extraOptions = append(extraOptions,
+			mcp.WithString("apiVersion",
+				mcp.Description("The apiVersion of the resource, e.g. cert-manager.io/v1 (optional with resource, the preferred version is used when left out)"),
+			),
+			mcp.WithString("kind",
+				mcp.Description("The kind of the resource, e.g. Certificate"),
+			),
+			mcp.WithString("resource",
+				mcp.Description("The resource name instead of kind, e.g. certificates, certificates.cert-manager.io or deploy"),
+			),
+			mcp.WithString("patchType",
+				mcp.Description("The format of the patch in resourceSpec, defaults to merge (used for patch action)"),
+				mcp.Enum(patchTypes...),
+			),
+			mcp.WithString("labelSelector",
+				mcp.Description("Only list objects matching this label selector, e.g. app=web (used for list action)"),
+			),
+			mcp.WithArray("reveal",
+				mcp.Description("Keys of a Secret whose values should be returned in clear text (used for get/create/patch/apply actions). Secret values are redacted to their length and SHA-256 fingerprint by default"),
+				mcp.WithStringItems(),
+			),
+		)
+	case pdb:
+		description = "Tool for managing PodDisruptionBudget resources in Kubernetes. " +
+			"The status action lists every PDB in the namespace (all namespaces when empty) with its matched pods and disruption health, " +
+			"flagging PDBs that match no pods or block all voluntary disruptions; name is only required for the other actions"
+		actions = append(actions, "status")
+	case quotaUsage:
+		description = "Tool for reporting the used vs hard amount of each ResourceQuota resource in a namespace, in percent, " +
+			"and for predicting whether a pod would fit within the quotas after the LimitRange defaults and constraints are applied. " +
+			"The name optionally limits both actions to one quota"
+		actions = []string{"report", "predict"}
+		resourceSpecDescription = "A pod or pod spec in JSON format (used for predict action)"
+	case rbac:
+		description = "Tool for inspecting RBAC permissions: whether the current user, a user or a service account can perform a request (can-i), " +
+			"the rules the current user has in a namespace (rules) and every subject bound to a role granting a permission (who-can). " +
+			"The name is the optional name of the object the request is about, and an empty namespace means cluster-wide"
+		actions = []string{"can-i", "rules", "who-can"}
+		extraOptions = append(extraOptions,
+			mcp.WithString("verb",
+				mcp.Description("The verb to check, e.g. get, list, create or delete (used for can-i and who-can actions)"),
+			),
+			mcp.WithString("resource",
+				mcp.Description("The resource to check as resource[.group][/subresource], e.g. pods, deployments.apps or pods/log, or a non-resource URL like /healthz (used for can-i and who-can actions)"),
+			),
+			mcp.WithString("user",
+				mcp.Description("Check for this user instead of the current one (used for can-i action)"),
+			),
+			mcp.WithArray("groups",
+				mcp.Description("The groups of the user to check for (used for can-i action)"),
+				mcp.WithStringItems(),
+			),
+			mcp.WithString("serviceAccount",
+				mcp.Description("Check for this service account instead of the current user, as namespace:name or a name in the given namespace (used for can-i action)"),
+			),
+		)
+	case certificate:
+		description = "Tool for inspecting the certificates stored in kubernetes.io/tls secrets, their expiry and the ingresses using them"
+		actions = []string{"inspect", "expiring"}
+		extraOptions = append(extraOptions,
+			mcp.WithNumber("days",
+				mcp.Description("List certificates expiring within this many days (used for expiring action, defaults to 30)"),
+			),
+		)
+	case configmap, secret:
+		actions = append(actions, "patch", "apply", "consumers")
+		extraOptions = append(extraOptions,
+			mcp.WithBoolean("restartConsumers",
+				mcp.Description("Rolling restart every consuming workload, one at a time, after the change (used for update/patch/apply actions)"),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description("The maximum time to wait for each restarted workload to roll out in seconds (used with restartConsumers, defaults to 300)"),
+			),
+		)
+	}
+	switch tool {
+	case configmap:
+		resourceSpecDescription = "The specification for the configmap resource in JSON format (optional, used for create/update/patch/apply actions). " +
+			"For create it may instead hold sources like kubectl create configmap: fromLiteral (key=value list), fromFile (list of {path, key, content, encoding}), " +
+			"fromDirectory (list of {path, files}) and fromEnvFile (list of env file contents). Set encoding to base64 for binary content"
+	case secret:
+		resourceSpecDescription = "The specification for the secret resource in JSON format (optional, used for create/update actions). " +
+			"With secretType it holds plain inputs instead: server, username, password and email for docker-registry; cert and key (PEM) for tls; " +
+			"username and password for basic-auth; privateKey for ssh-auth; literals (key=value list) for generic"
+		extraOptions = append(extraOptions,
+			mcp.WithString("secretType",
+				mcp.Description("Build a typed secret from plain inputs in resourceSpec (used for create action)"),
+				mcp.Enum(secretTypes...),
+			),
+			mcp.WithArray("reveal",
+				mcp.Description("Keys whose values should be returned in clear text (used for create/update/get actions). Values are redacted to their length and SHA-256 fingerprint by default"),
+				mcp.WithStringItems(),
+			),
+		)
+	case service:
+		actions = append(actions, "endpoints")
+	case statefulset:
+		actions = append(actions, "claims")
+	case ingress:
+		actions = append(actions, "routes")
+	case hpa:
+		description = "Tool for managing HorizontalPodAutoscaler (autoscaling/v2) resources in Kubernetes. " +
+			"The explain action reports current and target metrics, conditions and bounds, and why the target is or is not being scaled"
+		actions = append(actions, "explain")
+	case networkpolicy:
+		description = "Tool for managing networkpolicy resources in Kubernetes. " +
+			"The analyze action decides whether traffic from a source to the pod given by name and namespace is allowed, naming the rules that allow or block it"
+		actions = append(actions, "analyze")
+		extraOptions = append(extraOptions,
+			mcp.WithString("sourcePod",
+				mcp.Description("The source pod (used for analyze action)"),
+			),
+			mcp.WithString("sourceNamespace",
+				mcp.Description("The namespace of the source, defaults to the destination namespace (used for analyze action)"),
+			),
+			mcp.WithArray("sourceLabels",
+				mcp.Description("Labels of the source as key=value, used instead of sourcePod for pods that do not exist yet; omit them with sourceNamespace for any pod in that namespace (used for analyze action)"),
+				mcp.WithStringItems(),
+			),
+			mcp.WithString("port",
+				mcp.Description("The destination port, as a number or a named container port (used for analyze action)"),
+			),
+			mcp.WithString("protocol",
+				mcp.Description("The protocol of the traffic, defaults to TCP (used for analyze action)"),
+				mcp.Enum(networkProtocols...),
+			),
+		)
+	case persistentvolumeclaim:
+		actions = append(actions, "resize")
+		extraOptions = append(extraOptions,
+			mcp.WithString("size",
+				mcp.Description("The new storage request, e.g. 20Gi (used for resize action, must be larger than the current request)"),
+			),
+		)
+	case job:
+		actions = append(actions, "suspend", "resume", "retry", "wait")
+		extraOptions = append(extraOptions,
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description("The maximum time to wait in seconds (used for wait action, defaults to 300)"),
+			),
+		)
+	case cronjob:
+		actions = append(actions, "trigger", "suspend", "resume", "schedule")
+		extraOptions = append(extraOptions,
+			mcp.WithNumber("count",
+				mcp.Description("The number of upcoming fire times to return (used for schedule action, defaults to 5)"),
+			),
+		)
+	}
+
+	if toolKinds[tool] != "" {
+		actions = append(actions, "describe")
+		extraOptions = append(extraOptions,
+			mcp.WithBoolean("includeEvents",
+				mcp.Description("Include the object's recent events (used for get action)"),
+			),
+		)
+	}
+
+	nameOptions := []mcp.PropertyOption{mcp.Description("The name of the " + tool + " resource")}
+	if !optionalName[tool] {
+		nameOptions = append(nameOptions, mcp.Required())
+	}
+	namespaceOptions := []mcp.PropertyOption{mcp.Description("The namespace where the " + tool + " resource is located")}
+	if optionalNamespace[tool] {
+		namespaceOptions = []mcp.PropertyOption{mcp.Description("The namespace where the " + tool + " resource is located (leave empty for all namespaces)")}
+	} else {
+		namespaceOptions = append(namespaceOptions, mcp.Required())
+	}
+
+	options := []mcp.ToolOption{
+		mcp.WithDescription(description),
+		mcp.WithString("name", nameOptions...),
+	}
+	if !clusterScoped[tool] {
+		options = append(options, mcp.WithString("namespace", namespaceOptions...))
+	}
+	options = append(options,
 		mcp.WithString("action",
 			mcp.Required(),
 			mcp.Description("The action to perform on the "+tool+" resource (e.g., create, delete, update, get)"),
-			mcp.Enum("create", "delete", "update", "get", "list"),
+			mcp.Enum(actions...),
 		),
 		mcp.WithString("resourceSpec",
-			mcp.Description("The specification for the "+tool+" resource in JSON format (optional, used for create/update actions)"),
+			mcp.Description(resourceSpecDescription),
 		),
 	)
 
+	resourceTool := mcp.NewTool(tool, append(options, extraOptions...)...)
+
 	return resourceTool
 }
 
-func addTool(ctx context.Context, server *server.MCPServer, tool mcp.Tool, kubernetesClient *kubernetes.Clientset) {
+func addTool(ctx context.Context, server *server.MCPServer, tool mcp.Tool, kubernetesConfig *rest.Config, kubernetesClient *kubernetes.Clientset, dynamicClient dynamic.Interface, cachedDiscovery discovery.CachedDiscoveryInterface, restMapper meta.RESTMapper, allowSecretReveal bool) {
 
 	server.AddTool(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Implement the logic to handle the tool request
@@ -75,12 +453,12 @@ func addTool(ctx context.Context, server *server.MCPServer, tool mcp.Tool, kuber
 		var mcpResult *mcp.CallToolResult
 		var err error
 		name, err := request.RequireString("name")
-		if err != nil {
+		if err != nil && !optionalName[tool.GetName()] {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		namespace, err := request.RequireString("namespace")
-		if err != nil {
+		if err != nil && !optionalNamespace[tool.GetName()] && !clusterScoped[tool.GetName()] {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
@@ -90,6 +468,25 @@ func addTool(ctx context.Context, server *server.MCPServer, tool mcp.Tool, kuber
 		}
 
 		resourceSpec := request.GetString("resourceSpec", "")
+		images := request.GetStringSlice("images", nil)
+		count := request.GetInt("count", 0)
+		timeoutSeconds := request.GetInt("timeoutSeconds", 0)
+		reveal := request.GetStringSlice("reveal", nil)
+		secretType := request.GetString("secretType", "")
+		days := request.GetInt("days", 0)
+		restartConsumers := request.GetBool("restartConsumers", false)
+		podSecurity := request.GetString("podSecurity", "")
+		includeEvents := request.GetBool("includeEvents", false)
+		drain := drainOptions{
+			DeleteEmptyDirData: request.GetBool("deleteEmptyDirData", false),
+			Force:              request.GetBool("force", false),
+			DryRun:             request.GetBool("dryRun", false),
+			Timeout:            time.Duration(timeoutSeconds) * time.Second,
+		}
+
+		if action == "describe" && toolKinds[tool.GetName()] != "" {
+			return describeMCPResponse(ctx, tool.GetName(), name, namespace, kubernetesClient)
+		}
 
 		switch tool.GetName() {
 		case pod:
@@ -98,53 +495,184 @@ func addTool(ctx context.Context, server *server.MCPServer, tool mcp.Tool, kuber
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		case deployment:
-			mcpResult, err = deploymentMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.AppsV1().Deployments(namespace))
+			mcpResult, err = deploymentMCPResponse(ctx, name, namespace, action, resourceSpec, images, kubernetesClient.AppsV1().Deployments(namespace))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		case statefulset:
-			mcpResult, err = statefulsetMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.AppsV1().StatefulSets(namespace))
+			mcpResult, err = statefulsetMCPResponse(ctx, name, namespace, action, resourceSpec, images, kubernetesClient.AppsV1().StatefulSets(namespace), kubernetesClient.CoreV1().PersistentVolumeClaims(namespace), kubernetesClient.CoreV1().Pods(namespace))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		case daemonset:
-			mcpResult, err = daemonsetMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.AppsV1().DaemonSets(namespace))
+			mcpResult, err = daemonsetMCPResponse(ctx, name, namespace, action, resourceSpec, images, kubernetesClient.AppsV1().DaemonSets(namespace))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		case replicaset:
-			mcpResult, err = replicasetMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.AppsV1().ReplicaSets(namespace))
+			mcpResult, err = replicasetMCPResponse(ctx, name, namespace, action, resourceSpec, images, kubernetesClient.AppsV1().ReplicaSets(namespace))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		case job:
-			mcpResult, err = jobMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.BatchV1().Jobs(namespace))
+			mcpResult, err = jobMCPResponse(ctx, name, namespace, action, resourceSpec, images, timeoutSeconds, kubernetesClient.BatchV1().Jobs(namespace), kubernetesClient.CoreV1().Pods(namespace))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		case cronjob:
-			mcpResult, err = cronjobMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.BatchV1().CronJobs(namespace))
+			mcpResult, err = cronjobMCPResponse(ctx, name, namespace, action, resourceSpec, images, count, kubernetesClient.BatchV1().CronJobs(namespace), kubernetesClient.BatchV1().Jobs(namespace))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		case service:
-			mcpResult, err = serviceMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.CoreV1().Services(namespace))
+			mcpResult, err = serviceMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.CoreV1().Services(namespace), kubernetesClient.DiscoveryV1().EndpointSlices(namespace), kubernetesClient.CoreV1().Pods(namespace))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		case configmap:
-			mcpResult, err = configmapMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.CoreV1().ConfigMaps(namespace))
+			mcpResult, err = configmapMCPResponse(ctx, name, namespace, action, resourceSpec, restartConsumers, timeoutSeconds, kubernetesClient.CoreV1().ConfigMaps(namespace), kubernetesClient)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		case secret:
-			mcpResult, err = secretMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.CoreV1().Secrets(namespace))
+			mcpResult, err = secretMCPResponse(ctx, name, namespace, action, resourceSpec, secretType, reveal, allowSecretReveal, restartConsumers, timeoutSeconds, kubernetesClient.CoreV1().Secrets(namespace), kubernetesClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case certificate:
+			mcpResult, err = certificateMCPResponse(ctx, name, namespace, action, days, kubernetesClient.CoreV1().Secrets(namespace), kubernetesClient.NetworkingV1().Ingresses(namespace))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case namespaceResource:
+			mcpResult, err = namespaceMCPResponse(ctx, name, action, resourceSpec, podSecurity, kubernetesClient.CoreV1().Namespaces(), kubernetesClient.Discovery(), dynamicClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case node:
+			mcpResult, err = nodeMCPResponse(ctx, name, action, drain, kubernetesClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case eventsResource:
+			mcpResult, err = eventsMCPResponse(ctx, namespace, action, eventFilter{
+				API:    request.GetString("api", ""),
+				Kind:   request.GetString("kind", ""),
+				Name:   name,
+				Type:   request.GetString("type", ""),
+				Reason: request.GetString("reason", ""),
+				Since:  time.Duration(request.GetInt("sinceMinutes", 0)) * time.Minute,
+			}, kubernetesClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case persistentvolumeclaim:
+			mcpResult, err = persistentvolumeclaimMCPResponse(ctx, name, namespace, action, resourceSpec, request.GetString("size", ""), kubernetesClient.CoreV1().PersistentVolumeClaims(namespace), kubernetesClient.StorageV1().StorageClasses())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case persistentvolume:
+			mcpResult, err = persistentvolumeMCPResponse(ctx, name, action, resourceSpec, kubernetesClient.CoreV1().PersistentVolumes())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case storageclass:
+			mcpResult, err = storageclassMCPResponse(ctx, name, action, resourceSpec, kubernetesClient.StorageV1().StorageClasses())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case ingress:
+			mcpResult, err = ingressMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.NetworkingV1().Ingresses(namespace), kubernetesClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case ingressclass:
+			mcpResult, err = ingressclassMCPResponse(ctx, name, action, resourceSpec, kubernetesClient.NetworkingV1().IngressClasses())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case networkpolicy:
+			mcpResult, err = networkpolicyMCPResponse(ctx, name, namespace, action, resourceSpec, trafficQuery{
+				SourcePod:       request.GetString("sourcePod", ""),
+				SourceNamespace: request.GetString("sourceNamespace", ""),
+				SourceLabels:    request.GetStringSlice("sourceLabels", nil),
+				Port:            request.GetString("port", ""),
+				Protocol:        request.GetString("protocol", ""),
+			}, kubernetesClient.NetworkingV1().NetworkPolicies(namespace), kubernetesClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case hpa:
+			mcpResult, err = hpaMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.AutoscalingV2().HorizontalPodAutoscalers(namespace), kubernetesClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case rbac:
+			mcpResult, err = rbacMCPResponse(ctx, action, accessQuery{
+				Verb:           request.GetString("verb", ""),
+				Resource:       request.GetString("resource", ""),
+				ResourceName:   name,
+				Namespace:      namespace,
+				User:           request.GetString("user", ""),
+				Groups:         request.GetStringSlice("groups", nil),
+				ServiceAccount: request.GetString("serviceAccount", ""),
+			}, kubernetesClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case serviceaccount:
+			mcpResult, err = serviceaccountMCPResponse(ctx, name, namespace, action, resourceSpec, tokenOptions{
+				Audiences:  request.GetStringSlice("audiences", nil),
+				Expiration: time.Duration(request.GetInt("expirationSeconds", 0)) * time.Second,
+			}, allowSecretReveal, kubernetesClient.CoreV1().ServiceAccounts(namespace), kubernetesConfig)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case resourcequota:
+			mcpResult, err = resourcequotaMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.CoreV1().ResourceQuotas(namespace))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case limitrange:
+			mcpResult, err = limitrangeMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.CoreV1().LimitRanges(namespace))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case quotaUsage:
+			mcpResult, err = quotaUsageMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case pdb:
+			mcpResult, err = pdbMCPResponse(ctx, name, namespace, action, resourceSpec, kubernetesClient.PolicyV1().PodDisruptionBudgets(namespace), kubernetesClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case genericResource:
+			mcpResult, err = resourceMCPResponse(ctx, name, namespace, action, resourceSpec, resourceType{
+				APIVersion: request.GetString("apiVersion", ""),
+				Kind:       request.GetString("kind", ""),
+				Resource:   request.GetString("resource", ""),
+			}, request.GetString("patchType", ""), request.GetString("labelSelector", ""), reveal, allowSecretReveal, restMapper, dynamicClient)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		case apiResourcesTool:
+			mcpResult, err = apiResourcesMCPResponse(action, apiResourceFilter{
+				Group: request.GetString("group", ""),
+				Verb:  request.GetString("verb", ""),
+				Name:  name,
+			}, request.GetBool("refresh", false), cachedDiscovery)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 		default:
 			return mcp.NewToolResultError("Unknown tool: " + tool.GetName()), nil
 		}
+
+		if action == "get" && includeEvents && toolKinds[tool.GetName()] != "" && !mcpResult.IsError {
+			return withRecentEvents(ctx, mcpResult, kubernetesClient, namespace, toolKinds[tool.GetName()], name)
+		}
 		return mcpResult, err
 	})
 }